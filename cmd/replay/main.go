@@ -0,0 +1,89 @@
+// Command replay validates every request of a JSONL corpus with several request
+// validator implementations and reports their verdicts.
+//
+// Usage:
+//
+//	go run ./cmd/replay -corpus corpus/testdata/requests.jsonl -validators kin,go
+//
+// The kin validator can enforce a spec split into several files with -kin-spec.
+//
+// The exit status is 1 if any verdict does not match the expected outcome of its request.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+
+	"request_validator/corpus"
+	http_v1 "request_validator/http/v1"
+	http_v2 "request_validator/http/v2"
+	"request_validator/spec/loader"
+	"request_validator/validator"
+)
+
+func main() {
+	corpusPath := flag.String("corpus", "corpus/testdata/requests.jsonl", "path to the JSONL corpus of recorded requests")
+	validators := flag.String("validators", "kin,go", "comma separated list of the validators to replay (kin, go, hybrid)")
+	kinSpec := flag.String("kin-spec", "", "path to the spec file or directory of the kin validator, the embedded v1 spec if empty")
+	flag.Parse()
+
+	ctx := context.Background()
+
+	entries, err := corpus.ReadFile(*corpusPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	candidates, err := createCandidates(ctx, strings.Split(*validators, ","), *kinSpec)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	report, err := corpus.Replay(ctx, entries, candidates)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	report.Write(os.Stdout)
+	if report.Mismatches() > 0 {
+		os.Exit(1)
+	}
+}
+
+func createCandidates(ctx context.Context, names []string, kinSpec string) ([]corpus.Candidate, error) {
+	candidates := make([]corpus.Candidate, 0, len(names))
+	for _, name := range names {
+		impl := validator.Implementation(strings.TrimSpace(name))
+
+		// the kin validator enforces the OpenAPI spec while the others rely on the tags of v2,
+		// whose spec gives them the servers to check
+		var doc *openapi3.T
+		var err error
+		switch impl {
+		case validator.Kin:
+			if kinSpec != "" {
+				doc, err = loader.FromFile(ctx, kinSpec)
+			} else {
+				doc, err = http_v1.GetSwagger()
+			}
+		case validator.Go, validator.Hybrid:
+			doc, err = http_v2.GetSwagger()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to load the %q validator spec: %w", impl, err)
+		}
+
+		v, err := validator.New[http_v2.CreateUserReq](ctx, impl, doc)
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, corpus.Candidate{Name: string(impl), Validator: v})
+	}
+	return candidates, nil
+}
@@ -0,0 +1,40 @@
+// Command specdrift compares the plain OpenAPI spec of the OpenAPI validator with the tagged
+// spec of the Go validator, printing the properties whose constraints differ.
+//
+// Usage:
+//
+//	go run ./cmd/specdrift -v1 http/v1/api.yaml -v2 http/v2/api.yaml
+//
+// It exits with status 1 when the specs drift. The constraints of the plain spec that can't
+// be expressed as validate tags are reported on the standard error.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"request_validator/spec/drift"
+)
+
+func main() {
+	v1 := flag.String("v1", "http/v1/api.yaml", "path to the plain OpenAPI spec")
+	v2 := flag.String("v2", "http/v2/api.yaml", "path to the spec with the validate tags")
+	flag.Parse()
+
+	report, err := drift.LoadFiles(*v1, *v2)
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, warning := range report.Unchecked {
+		fmt.Fprintf(os.Stderr, "unchecked: %s\n", warning)
+	}
+	for _, d := range report.Drifts {
+		fmt.Println(d)
+	}
+	if len(report.Drifts) > 0 {
+		fmt.Fprintf(os.Stderr, "the specs have %d drifts\n", len(report.Drifts))
+		os.Exit(1)
+	}
+}
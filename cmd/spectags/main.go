@@ -0,0 +1,58 @@
+// Command spectags derives the spec of the Go validator from a plain OpenAPI document,
+// writing the validate tags of the constraints of every property in its oapi-codegen extra
+// tags.
+//
+// Usage:
+//
+//	go run ./cmd/spectags -in http/v1/api.yaml -out http/v2/api.yaml
+//
+// The constraints that can't be expressed as tags are reported on the standard error.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"request_validator/spec/tags"
+)
+
+func main() {
+	in := flag.String("in", "", "path to the plain OpenAPI document")
+	out := flag.String("out", "", "path to the tagged document, the standard output if empty")
+	keepFormats := flag.Bool("keep-formats", false, "keep the string formats that are turned into validate tags")
+	flag.Parse()
+
+	if *in == "" {
+		log.Fatal("the -in document is required")
+	}
+	src, err := os.ReadFile(*in)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	opts := []tags.Option{
+		tags.WithHeader(fmt.Sprintf("Code generated by spectags from %s. DO NOT EDIT.", filepath.ToSlash(*in))),
+	}
+	if *keepFormats {
+		opts = append(opts, tags.WithKeepFormats())
+	}
+	tagged, warnings, err := tags.Transform(src, opts...)
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, warning := range warnings {
+		fmt.Fprintf(os.Stderr, "warning: %s\n", warning)
+	}
+
+	if *out == "" {
+		_, err = os.Stdout.Write(tagged)
+	} else {
+		err = os.WriteFile(*out, tagged, 0o644)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
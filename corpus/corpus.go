@@ -0,0 +1,107 @@
+// Package corpus defines the JSONL format of the recorded requests that can be replayed
+// through the request validators.
+//
+// Every line of a corpus file is a JSON object such as:
+//
+//	{"method":"POST","url":"http://api.example.com/v1/users/create","headers":{"Content-Type":["application/json"]},"body":"{\"firstName\":\"Jon\"}","expect":"reject"}
+//
+// The body is stored as a string so malformed payloads can be recorded as they were received.
+package corpus
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// maxLineSize is the size of the longest line that can be read from a corpus file.
+const maxLineSize = 16 << 20
+
+// Outcome is the expected verdict of the validators for a recorded request.
+type Outcome string
+
+const (
+	Accept Outcome = "accept"
+	Reject Outcome = "reject"
+)
+
+// Entry is a recorded request.
+type Entry struct {
+	Method  string      `json:"method"`
+	URL     string      `json:"url"`
+	Headers http.Header `json:"headers,omitempty"`
+	Body    string      `json:"body,omitempty"`
+	// Truncated reports whether the body was truncated when it was recorded.
+	Truncated bool `json:"truncated,omitempty"`
+	// Expect is the optional expected outcome of the validation.
+	Expect Outcome `json:"expect,omitempty"`
+	// Error is the validation error the request got when it was recorded, if any.
+	Error string `json:"error,omitempty"`
+
+	// Line is the line of the corpus file the entry was read from.
+	Line int `json:"-"`
+}
+
+// Request creates the http request described by the entry.
+func (e Entry) Request(ctx context.Context) (*http.Request, error) {
+	r, err := http.NewRequestWithContext(ctx, e.Method, e.URL, strings.NewReader(e.Body))
+	if err != nil {
+		return nil, fmt.Errorf("unable to create request: %w", err)
+	}
+	for name, values := range e.Headers {
+		for _, value := range values {
+			r.Header.Add(name, value)
+		}
+	}
+	return r, nil
+}
+
+// Read reads every entry of a corpus, skipping the blank lines.
+func Read(r io.Reader) ([]Entry, error) {
+	var entries []Entry
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+	for line := 1; scanner.Scan(); line++ {
+		data := bytes.TrimSpace(scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+
+		var entry Entry
+		if err := json.Unmarshal(data, &entry); err != nil {
+			return nil, fmt.Errorf("unable to decode corpus line %d: %w", line, err)
+		}
+		if entry.Method == "" || entry.URL == "" {
+			return nil, fmt.Errorf("corpus line %d should have a method and a url", line)
+		}
+		switch entry.Expect {
+		case "", Accept, Reject:
+		default:
+			return nil, fmt.Errorf("corpus line %d has an unknown expected outcome %q", line, entry.Expect)
+		}
+		entry.Line = line
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("unable to read corpus: %w", err)
+	}
+	return entries, nil
+}
+
+// ReadFile reads every entry of the corpus file.
+func ReadFile(path string) ([]Entry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open corpus: %w", err)
+	}
+	defer f.Close()
+
+	return Read(f)
+}
@@ -0,0 +1,86 @@
+package corpus
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRead(t *testing.T) {
+	tests := []struct {
+		name     string
+		corpus   string
+		wantFunc func(t *testing.T, entries []Entry, err error)
+	}{
+		{
+			name:   "given a corpus with blank lines, when we read it, the entries should keep their line numbers",
+			corpus: "\n" + `{"method":"POST","url":"http://api.example.com/v1/users/create","body":"{}","expect":"reject"}` + "\n\n" + `{"method":"GET","url":"http://api.example.com/v1/users"}`,
+			wantFunc: func(t *testing.T, entries []Entry, err error) {
+				require.NoError(t, err, "read should not error")
+				require.Len(t, entries, 2)
+				require.Equal(t, 2, entries[0].Line)
+				require.Equal(t, Reject, entries[0].Expect)
+				require.Equal(t, "{}", entries[0].Body)
+				require.Equal(t, 4, entries[1].Line)
+				require.Equal(t, http.MethodGet, entries[1].Method)
+			},
+		},
+		{
+			name:   "given a corpus with a malformed line, when we read it, an error with the line number should be returned",
+			corpus: `{"method":"POST","url":"http://api.example.com"}` + "\n" + `{"method":`,
+			wantFunc: func(t *testing.T, entries []Entry, err error) {
+				require.ErrorContains(t, err, "line 2")
+			},
+		},
+		{
+			name:   "given a corpus with an entry without url, when we read it, an error should be returned",
+			corpus: `{"method":"POST"}`,
+			wantFunc: func(t *testing.T, entries []Entry, err error) {
+				require.ErrorContains(t, err, "should have a method and a url")
+			},
+		},
+		{
+			name:   "given a corpus with an unknown expected outcome, when we read it, an error should be returned",
+			corpus: `{"method":"POST","url":"http://api.example.com","expect":"maybe"}`,
+			wantFunc: func(t *testing.T, entries []Entry, err error) {
+				require.ErrorContains(t, err, "unknown expected outcome")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// act
+			entries, err := Read(strings.NewReader(tt.corpus))
+
+			// assert
+			tt.wantFunc(t, entries, err)
+		})
+	}
+}
+
+func TestEntryRequest(t *testing.T) {
+	// arrange
+	entry := Entry{
+		Method:  http.MethodPost,
+		URL:     "http://api.example.com/v1/users/create",
+		Headers: http.Header{"Content-Type": {"application/json"}},
+		Body:    `{"firstName": "Jon"}`,
+	}
+
+	// act
+	r, err := entry.Request(context.Background())
+
+	// assert
+	require.NoError(t, err, "request creation should not error")
+	require.Equal(t, http.MethodPost, r.Method)
+	require.Equal(t, "api.example.com", r.URL.Host)
+	require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+	body, err := io.ReadAll(r.Body)
+	require.NoError(t, err, "body should be readable")
+	require.Equal(t, entry.Body, string(body))
+}
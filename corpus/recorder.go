@@ -0,0 +1,287 @@
+package corpus
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	"request_validator/validator"
+)
+
+// Redacted replaces the values of the redacted headers and body fields.
+const Redacted = "[REDACTED]"
+
+// RecorderOption configures the recorder.
+type RecorderOption func(*Recorder)
+
+// WithRedactedHeaders replaces the values of the given headers. The Authorization,
+// Proxy-Authorization and Cookie headers are always redacted.
+func WithRedactedHeaders(names ...string) RecorderOption {
+	return func(rec *Recorder) {
+		for _, name := range names {
+			rec.redactedHeaders[http.CanonicalHeaderKey(name)] = struct{}{}
+		}
+	}
+}
+
+// WithRedactedFields replaces the values of the JSON body fields with the given names, at
+// any depth of the document. Bodies that are not valid JSON can't be redacted, so they are
+// replaced as a whole.
+func WithRedactedFields(names ...string) RecorderOption {
+	return func(rec *Recorder) {
+		for _, name := range names {
+			rec.redactedFields[name] = struct{}{}
+		}
+	}
+}
+
+// WithMaxBodySize truncates the recorded bodies to the given number of bytes.
+func WithMaxBodySize(size int) RecorderOption {
+	return func(rec *Recorder) {
+		rec.maxBodySize = size
+	}
+}
+
+// WithMaxSize stops recording once the given number of bytes has been written.
+func WithMaxSize(size int64) RecorderOption {
+	return func(rec *Recorder) {
+		rec.maxSize = size
+	}
+}
+
+// WithSampleRate records a fraction, between 0 and 1, of the accepted requests on top of
+// every rejected one.
+func WithSampleRate(rate float64) RecorderOption {
+	return func(rec *Recorder) {
+		rec.sampleRate = rate
+	}
+}
+
+// Recorder appends the rejected, and optionally a sample of the accepted, requests to a
+// corpus so they can be replayed later.
+type Recorder struct {
+	redactedHeaders map[string]struct{}
+	redactedFields  map[string]struct{}
+	maxBodySize     int
+	maxSize         int64
+	sampleRate      float64
+
+	mu      sync.Mutex
+	w       io.Writer
+	closer  io.Closer
+	written int64
+	dropped int
+}
+
+func NewRecorder(w io.Writer, opts ...RecorderOption) *Recorder {
+	rec := &Recorder{
+		w: w,
+		redactedHeaders: map[string]struct{}{
+			"Authorization":       {},
+			"Proxy-Authorization": {},
+			"Cookie":              {},
+		},
+		redactedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(rec)
+	}
+	return rec
+}
+
+// OpenRecorder creates a recorder that appends the requests to the corpus file at path.
+func OpenRecorder(path string, opts ...RecorderOption) (*Recorder, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open corpus: %w", err)
+	}
+
+	rec := NewRecorder(f, opts...)
+	rec.closer = f
+	return rec, nil
+}
+
+// Close closes the corpus file opened by OpenRecorder.
+func (rec *Recorder) Close() error {
+	if rec.closer == nil {
+		return nil
+	}
+	return rec.closer.Close()
+}
+
+// Dropped returns the number of requests that were not recorded because the maximum size was reached.
+func (rec *Recorder) Dropped() int {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+
+	return rec.dropped
+}
+
+// Record appends the request to the corpus if it was rejected by the validation, that is,
+// if err is not nil, or if it is part of the sample of accepted requests. The body of the
+// request must still be readable and it is restored after being read.
+func (rec *Recorder) Record(r *http.Request, err error) error {
+	if err == nil && (rec.sampleRate <= 0 || rand.Float64() >= rec.sampleRate) {
+		return nil
+	}
+
+	body, readErr := readBody(r)
+	if readErr != nil {
+		return readErr
+	}
+	return rec.record(r, body, err)
+}
+
+// Wrap returns a request validator that records the requests validated by v.
+func (rec *Recorder) Wrap(v validator.RequestValidator) validator.RequestValidator {
+	return validator.RequestValidatorFunc(func(ctx context.Context, r *http.Request) error {
+		// the body is read beforehand since the validator may consume it
+		body, err := readBody(r)
+		if err != nil {
+			return err
+		}
+
+		err = v.ValidateRequest(ctx, r)
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		if err != nil || (rec.sampleRate > 0 && rand.Float64() < rec.sampleRate) {
+			// a failure to record should never change the verdict of the validator
+			_ = rec.record(r, body, err)
+		}
+		return err
+	})
+}
+
+// Responder returns an error responder for the validation middlewares that records
+// every rejected request before calling next.
+func (rec *Recorder) Responder(next func(w http.ResponseWriter, r *http.Request, err error)) func(w http.ResponseWriter, r *http.Request, err error) {
+	return func(w http.ResponseWriter, r *http.Request, err error) {
+		_ = rec.Record(r, err)
+		next(w, r, err)
+	}
+}
+
+func (rec *Recorder) record(r *http.Request, body []byte, err error) error {
+	entry := Entry{
+		Method:  r.Method,
+		URL:     r.URL.String(),
+		Headers: rec.redactHeaders(r.Header),
+		Body:    string(rec.redactBody(body)),
+	}
+	if rec.maxBodySize > 0 && len(entry.Body) > rec.maxBodySize {
+		entry.Body = entry.Body[:rec.maxBodySize]
+		entry.Truncated = true
+	}
+	if err != nil {
+		entry.Error = errorSummary(err)
+	}
+
+	line, marshalErr := json.Marshal(entry)
+	if marshalErr != nil {
+		return fmt.Errorf("unable to encode corpus entry: %w", marshalErr)
+	}
+	line = append(line, '\n')
+
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+
+	if rec.maxSize > 0 && rec.written+int64(len(line)) > rec.maxSize {
+		rec.dropped++
+		return nil
+	}
+	n, writeErr := rec.w.Write(line)
+	rec.written += int64(n)
+	if writeErr != nil {
+		return fmt.Errorf("unable to write corpus entry: %w", writeErr)
+	}
+	return nil
+}
+
+// errorSummary describes the violations of a validation error by their location, pointer and
+// rule only, as the messages of the implementations may include the offending values.
+func errorSummary(err error) string {
+	violations := validator.FromError(err).Violations
+	parts := make([]string, 0, len(violations))
+	for _, v := range violations {
+		part := string(v.Location)
+		if v.Pointer != "" {
+			part += " " + v.Pointer
+		}
+		parts = append(parts, part+": "+v.Rule)
+	}
+	return "request validation failed: " + strings.Join(parts, " | ")
+}
+
+func (rec *Recorder) redactHeaders(header http.Header) http.Header {
+	if len(header) == 0 {
+		return nil
+	}
+
+	redacted := header.Clone()
+	for name, values := range redacted {
+		if _, ok := rec.redactedHeaders[http.CanonicalHeaderKey(name)]; !ok {
+			continue
+		}
+		for i := range values {
+			values[i] = Redacted
+		}
+	}
+	return redacted
+}
+
+func (rec *Recorder) redactBody(body []byte) []byte {
+	if len(rec.redactedFields) == 0 || len(body) == 0 {
+		return body
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var doc interface{}
+	if err := decoder.Decode(&doc); err != nil {
+		return []byte(Redacted)
+	}
+
+	redacted, err := json.Marshal(rec.redactValue(doc))
+	if err != nil {
+		return []byte(Redacted)
+	}
+	return redacted
+}
+
+func (rec *Recorder) redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if _, ok := rec.redactedFields[key]; ok {
+				v[key] = Redacted
+				continue
+			}
+			v[key] = rec.redactValue(field)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = rec.redactValue(item)
+		}
+	}
+	return value
+}
+
+func readBody(r *http.Request) ([]byte, error) {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read request body: %w", err)
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
@@ -0,0 +1,172 @@
+package corpus
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	http_v1 "request_validator/http/v1"
+	http_v2 "request_validator/http/v2"
+	"request_validator/validator"
+	govalidator "request_validator/validator/go_validator"
+)
+
+const correctRequest = `{"id":"32d3e8f1-2f81-49c0-acb6-6dccd84f3dab","firstName":"Jon","lastName":"Snow"}`
+
+const missingMandatoryFieldRequest = `{"firstName":"Jon","lastName":"Snow","email":"jon_snow@winterfell.com"}`
+
+func TestRecorder(t *testing.T) {
+	ctx := context.Background()
+	swaggerDoc, err := http_v1.GetSwagger()
+	require.NoError(t, err, "swagger recovery should not error")
+	kin, err := validator.New[http_v2.CreateUserReq](ctx, validator.Kin, swaggerDoc)
+	require.NoError(t, err, "kin validator creation should not error")
+
+	tests := []struct {
+		name     string
+		opts     []RecorderOption
+		reqs     []string
+		wantFunc func(t *testing.T, entries []Entry, rec *Recorder)
+	}{
+		{
+			name: "given a rejected and an accepted request, when we record them, only the rejected one should be recorded with its error",
+			reqs: []string{correctRequest, missingMandatoryFieldRequest},
+			wantFunc: func(t *testing.T, entries []Entry, rec *Recorder) {
+				require.Len(t, entries, 1)
+				require.Equal(t, http.MethodPost, entries[0].Method)
+				require.Equal(t, "http://api.example.com/v1/users/create", entries[0].URL)
+				require.Equal(t, missingMandatoryFieldRequest, entries[0].Body)
+				require.Equal(t, "request validation failed: body /id: required", entries[0].Error)
+				require.Equal(t, []string{Redacted}, entries[0].Headers["Authorization"])
+				require.Equal(t, []string{"application/json"}, entries[0].Headers["Content-Type"])
+			},
+		},
+		{
+			name: "given redacted headers and body fields, when we record a rejected request, their values should be redacted",
+			opts: []RecorderOption{WithRedactedHeaders("content-type"), WithRedactedFields("email")},
+			reqs: []string{missingMandatoryFieldRequest},
+			wantFunc: func(t *testing.T, entries []Entry, rec *Recorder) {
+				require.Len(t, entries, 1)
+				require.Equal(t, []string{Redacted}, entries[0].Headers["Content-Type"])
+				require.JSONEq(t, `{"firstName":"Jon","lastName":"Snow","email":"[REDACTED]"}`, entries[0].Body)
+			},
+		},
+		{
+			name: "given redacted body fields, when we record a rejected request whose body is not valid JSON, the whole body should be redacted",
+			opts: []RecorderOption{WithRedactedFields("email")},
+			reqs: []string{`{"email":"jon_snow@winterfell.com"`},
+			wantFunc: func(t *testing.T, entries []Entry, rec *Recorder) {
+				require.Len(t, entries, 1)
+				require.Equal(t, Redacted, entries[0].Body)
+			},
+		},
+		{
+			name: "given a sample rate of 1, when we record an accepted request, it should be recorded without error",
+			opts: []RecorderOption{WithSampleRate(1)},
+			reqs: []string{correctRequest},
+			wantFunc: func(t *testing.T, entries []Entry, rec *Recorder) {
+				require.Len(t, entries, 1)
+				require.Empty(t, entries[0].Error)
+			},
+		},
+		{
+			name: "given a maximum body size, when we record a rejected request, its body should be truncated",
+			opts: []RecorderOption{WithMaxBodySize(10)},
+			reqs: []string{missingMandatoryFieldRequest},
+			wantFunc: func(t *testing.T, entries []Entry, rec *Recorder) {
+				require.Len(t, entries, 1)
+				require.Equal(t, missingMandatoryFieldRequest[:10], entries[0].Body)
+				require.True(t, entries[0].Truncated)
+			},
+		},
+		{
+			name: "given a maximum corpus size, when we record more requests than it fits, the rest should be dropped",
+			opts: []RecorderOption{WithMaxSize(400)},
+			reqs: []string{missingMandatoryFieldRequest, missingMandatoryFieldRequest, missingMandatoryFieldRequest},
+			wantFunc: func(t *testing.T, entries []Entry, rec *Recorder) {
+				require.Len(t, entries, 1)
+				require.Equal(t, 2, rec.Dropped())
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			var out bytes.Buffer
+			rec := NewRecorder(&out, tt.opts...)
+			recordingValidator := rec.Wrap(kin)
+
+			for _, req := range tt.reqs {
+				httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://api.example.com/v1/users/create", strings.NewReader(req))
+				require.NoError(t, err, "http request creation should not error")
+				httpRequest.Header.Add("Content-Type", "application/json")
+				httpRequest.Header.Add("Authorization", "Bearer secret")
+
+				// act
+				_ = recordingValidator.ValidateRequest(ctx, httpRequest)
+			}
+
+			// assert
+			entries, err := Read(&out)
+			require.NoError(t, err, "recorded corpus should be readable")
+			tt.wantFunc(t, entries, rec)
+		})
+	}
+}
+
+func TestRecorderResponder(t *testing.T) {
+	// arrange
+	var out bytes.Buffer
+	rec := NewRecorder(&out)
+	httpRequest := httptest.NewRequest(http.MethodPost, "http://api.example.com/v1/users/create", strings.NewReader(missingMandatoryFieldRequest))
+	recorder := httptest.NewRecorder()
+
+	responderCalled := false
+	responder := rec.Responder(func(w http.ResponseWriter, r *http.Request, err error) {
+		responderCalled = true
+	})
+
+	// act
+	responder(recorder, httpRequest, errors.New("invalid request"))
+
+	// assert
+	require.True(t, responderCalled, "next responder should be called")
+	entries, err := Read(&out)
+	require.NoError(t, err, "recorded corpus should be readable")
+	require.Len(t, entries, 1)
+	require.Equal(t, missingMandatoryFieldRequest, entries[0].Body)
+}
+
+func TestRecorderErrorWithoutValues(t *testing.T) {
+	// arrange
+	var out bytes.Buffer
+	rec := NewRecorder(&out, WithRedactedHeaders("X-Api-Key"))
+	httpRequest := httptest.NewRequest(http.MethodGet, "http://api.example.com/v1/users", nil)
+	httpRequest.Header.Set("X-Api-Key", "supersecret")
+	paramErr := govalidator.ParamErrors{{
+		In:    govalidator.ParamInHeader,
+		Name:  "X-Api-Key",
+		Rule:  "type",
+		Value: "supersecret",
+		Err:   errors.New("invalid syntax"),
+	}}
+
+	// act
+	err := rec.Record(httpRequest, paramErr)
+
+	// assert
+	require.NoError(t, err, "record should not error")
+	entries, err := Read(&out)
+	require.NoError(t, err, "recorded corpus should be readable")
+	require.Len(t, entries, 1)
+	require.Equal(t, []string{Redacted}, entries[0].Headers["X-Api-Key"])
+	require.NotContains(t, entries[0].Error, "supersecret")
+	require.Equal(t, "request validation failed: header /X-Api-Key: parse", entries[0].Error)
+}
@@ -0,0 +1,165 @@
+package corpus
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"request_validator/validator"
+)
+
+// Candidate is a named request validator whose verdicts are replayed.
+type Candidate struct {
+	Name      string
+	Validator validator.RequestValidator
+}
+
+// Verdict is the outcome of a candidate validating a recorded request.
+type Verdict struct {
+	Candidate string
+	Err       error
+	Duration  time.Duration
+}
+
+// Outcome returns whether the candidate accepted or rejected the request.
+func (v Verdict) Outcome() Outcome {
+	if v.Err != nil {
+		return Reject
+	}
+	return Accept
+}
+
+// Result holds the verdicts of every candidate for a recorded request.
+type Result struct {
+	Entry    Entry
+	Verdicts []Verdict
+}
+
+// Mismatches returns the verdicts that don't match the expected outcome of the entry.
+func (r Result) Mismatches() []Verdict {
+	if r.Entry.Expect == "" {
+		return nil
+	}
+
+	var mismatches []Verdict
+	for _, v := range r.Verdicts {
+		if v.Outcome() != r.Entry.Expect {
+			mismatches = append(mismatches, v)
+		}
+	}
+	return mismatches
+}
+
+// Agree reports whether every candidate gave the same verdict.
+func (r Result) Agree() bool {
+	for _, v := range r.Verdicts {
+		if v.Outcome() != r.Verdicts[0].Outcome() {
+			return false
+		}
+	}
+	return true
+}
+
+// Stats aggregates the verdicts of a candidate.
+type Stats struct {
+	Candidate  string
+	Accepted   int
+	Rejected   int
+	Mismatches int
+	Duration   time.Duration
+}
+
+// Report is the outcome of replaying a corpus.
+type Report struct {
+	Results []Result
+	Stats   []Stats
+	// Disagreements is the number of requests on which the candidates gave different verdicts.
+	Disagreements int
+}
+
+// Mismatches returns the number of verdicts that don't match their expected outcome.
+func (r *Report) Mismatches() int {
+	var mismatches int
+	for _, s := range r.Stats {
+		mismatches += s.Mismatches
+	}
+	return mismatches
+}
+
+// Replay validates every entry with every candidate.
+func Replay(ctx context.Context, entries []Entry, candidates []Candidate) (*Report, error) {
+	report := &Report{Stats: make([]Stats, len(candidates))}
+	for i, c := range candidates {
+		report.Stats[i].Candidate = c.Name
+	}
+
+	for _, entry := range entries {
+		result := Result{Entry: entry, Verdicts: make([]Verdict, 0, len(candidates))}
+		for i, c := range candidates {
+			// every candidate gets its own request since the validators may consume the body
+			r, err := entry.Request(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("corpus line %d: %w", entry.Line, err)
+			}
+
+			start := time.Now()
+			err = c.Validator.ValidateRequest(ctx, r)
+			verdict := Verdict{Candidate: c.Name, Err: err, Duration: time.Since(start)}
+			result.Verdicts = append(result.Verdicts, verdict)
+
+			stats := &report.Stats[i]
+			stats.Duration += verdict.Duration
+			if err != nil {
+				stats.Rejected++
+			} else {
+				stats.Accepted++
+			}
+			if entry.Expect != "" && verdict.Outcome() != entry.Expect {
+				stats.Mismatches++
+			}
+		}
+		if !result.Agree() {
+			report.Disagreements++
+		}
+		report.Results = append(report.Results, result)
+	}
+	return report, nil
+}
+
+// Write writes the verdicts of every request followed by the aggregated stats of every candidate.
+func (r *Report) Write(w io.Writer) {
+	for _, result := range r.Results {
+		fmt.Fprintf(w, "line %d: %s %s", result.Entry.Line, result.Entry.Method, result.Entry.URL)
+		if result.Entry.Expect != "" {
+			fmt.Fprintf(w, " (expect %s)", result.Entry.Expect)
+		}
+		fmt.Fprintln(w)
+
+		mismatches := make(map[string]bool)
+		for _, v := range result.Mismatches() {
+			mismatches[v.Candidate] = true
+		}
+		for _, v := range result.Verdicts {
+			fmt.Fprintf(w, "  %s: %s", v.Candidate, v.Outcome())
+			if v.Err != nil {
+				// the unified error keeps the verdicts on a single line
+				fmt.Fprintf(w, " (%s)", validator.FromError(v.Err))
+			}
+			if mismatches[v.Candidate] {
+				fmt.Fprint(w, " MISMATCH")
+			}
+			fmt.Fprintln(w)
+		}
+	}
+
+	fmt.Fprintf(w, "\nreplayed requests: %d, disagreements: %d, mismatches: %d\n", len(r.Results), r.Disagreements, r.Mismatches())
+	for _, s := range r.Stats {
+		var mean time.Duration
+		if total := s.Accepted + s.Rejected; total > 0 {
+			mean = s.Duration / time.Duration(total)
+		}
+		fmt.Fprintf(w, "%s: accepted %d, rejected %d, mismatches %d, total %s, mean %s\n",
+			s.Candidate, s.Accepted, s.Rejected, s.Mismatches, s.Duration, mean)
+	}
+}
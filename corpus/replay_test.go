@@ -0,0 +1,51 @@
+package corpus
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	http_v1 "request_validator/http/v1"
+	http_v2 "request_validator/http/v2"
+	"request_validator/validator"
+)
+
+func TestReplay(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	swaggerDoc, err := http_v1.GetSwagger()
+	require.NoError(t, err, "swagger recovery should not error")
+	kin, err := validator.New[http_v2.CreateUserReq](ctx, validator.Kin, swaggerDoc)
+	require.NoError(t, err, "kin validator creation should not error")
+	goValidator, err := validator.New[http_v2.CreateUserReq](ctx, validator.Go, nil)
+	require.NoError(t, err, "go validator creation should not error")
+
+	entries, err := ReadFile("testdata/requests.jsonl")
+	require.NoError(t, err, "corpus should be readable")
+
+	// act
+	report, err := Replay(ctx, entries, []Candidate{
+		{Name: "kin", Validator: kin},
+		{Name: "go", Validator: goValidator},
+	})
+
+	// assert
+	require.NoError(t, err, "replay should not error")
+	require.Len(t, report.Results, 4)
+	require.Equal(t, Stats{Candidate: "kin", Accepted: 1, Rejected: 3, Duration: report.Stats[0].Duration}, report.Stats[0])
+	require.Equal(t, Stats{Candidate: "go", Accepted: 2, Rejected: 2, Mismatches: 1, Duration: report.Stats[1].Duration}, report.Stats[1])
+	require.Equal(t, 1, report.Disagreements)
+	require.Equal(t, 1, report.Mismatches())
+
+	mismatches := report.Results[3].Mismatches()
+	require.Len(t, mismatches, 1)
+	require.Equal(t, "go", mismatches[0].Candidate)
+
+	var out bytes.Buffer
+	report.Write(&out)
+	require.Contains(t, out.String(), "line 5: POST http://unknown.example.com/v1/users/create (expect reject)")
+	require.Contains(t, out.String(), "go: accept MISMATCH")
+	require.Contains(t, out.String(), "replayed requests: 4, disagreements: 1, mismatches: 1")
+}
@@ -27,7 +27,7 @@ type CreateUserReq struct {
 	FirstName string `json:"firstName" validate:"required"`
 
 	// Id The user's unique identifier in UUID format
-	Id string `json:"id" validate:"required,uuid_rfc4122"`
+	Id string `json:"id" validate:"required,uuid"`
 
 	// LastName The user's last names
 	LastName string `json:"lastName" validate:"required"`
@@ -285,18 +285,17 @@ func ParsePostUsersCreateResponse(rsp *http.Response) (*PostUsersCreateResponse,
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/6xUXU8bOxD9K9bcK12Q9isBIdinW+hDIxWKWlAfEKoce3ZjWH9gz9JEaP97ZW8oCaio",
-	"VXmb9R57zpyZOQ8grHbWoKEA9QMEsUDNU3jikRNeBvSf8S4eOG8delKYfqPmqkvBkmvXIdQw56S5+b+1",
-	"tOC6EFZDBrRy8Vcgr0wLGSxzy53KhZXYoslxSZ7nxNv05j3vlOQUL1itCLWjVTYmGoYMGuUDnXGNESsx",
-	"CK8cKWughosFsj6g/y+whGImwrINcse+F/gXhDze9cqjTEyUfJVCb9Rdj0xJNKQahZ4pwy4vZ+9ZY73m",
-	"tEVsbyr38LCZ5NPmcJLvH4kq52J+kB9IIeThfrMn+fwNeGd9r+Q334j9yXSaiuj4b6gZQUnMsEX6K1+Z",
-	"N1JzyJ6+6qso7WanN2he/0xn5zcoaLyrTGNfVvApBbxjuu9Idcogs54FZdoO8/S5gY/duTqxWltzyv3t",
-	"9c6CyNVlKdKR5v62sL4tF9i5cje+8+Hi9GMRq1eU1PiSZGHvzmeQwT36MJKoiklxBEMG1qHhTkEN06Iq",
-	"KsjAcVokTcqocyhFWra0ZTbQK/XgktBIlM/5P9HfJBgXlkfITEIN5zZQ3OcwrjaMwmOgYytXMaewhtCk",
-	"9Ny5Tol0t7wJkcOjOcToX48N1PBP+eQe5do6ym3fGNYNDs6aMDrHtKpeFnhm2SOIqcAMYizypg/EaIFs",
-	"WlUsEKc+sDhbaX5DrzX3K6jXVhUYZwa/p8ktxuEI6GM7oL76paAjZFPOjGHRFuyUK8N2nLeyF/F4dw2F",
-	"DHrfQQ3rKeFOFevFiJ5X3k9gyP483cwQ+oQg3irTPiIb6xlhoHG/tjKvkfkzBjBcDz8CAAD//9sa3QvY",
-	"BQAA",
+	"H4sIAAAAAAAC/6xUW08rNxD+K9a0Us+R9paAEOxTC31opEJRC+oD4mHind0Y1hfsWZoI7X+v7A1NAipq",
+	"dXgbjz97vvnm8gLSamcNGQ5Qv0CQK9KYzAtPyHQbyP9OT9HhvHXkWVG6Jo2qT8YatesJalgiazQ/dpZX",
+	"qAtpNWTAGxevAntlOshgnVt0Kpe2oY5MTmv2mDN26c9n7FWDHB9YrZi04002BRrHDFrlA1+hpohtKEiv",
+	"HCtroIabFYkhkP8hiIQSJsKyPXLnfpD0DYQ8PQ3KU5OYqOZDCoNRTwMJ1ZBh1SryQhlxe7v4WbTWa+QD",
+	"Ykfz5ohO21k+b09n+fGZrHKUy5P8pJGyOT1ujxpcfgLvbBjURL7H/6Bij1sRwwHZP3FjPknFMdud6rso",
+	"6X6F92je/xPOLh9I8vRWmda+z+C3ZGAv9NCz6pUhYb0IynQ95em4h49VubuwWltzif7x/suK2dVlKZNL",
+	"o38srO/KFfWu/Br/+eXm8tciZq84qfFHkkX8dL2ADJ7Jh4lEVcyKMxgzsI4MOgU1zIuqqCADh7xKmpRR",
+	"51DKNGTR4WzgD/KhNZNpqHnLf0d/n2AcVIyQRQM1XNvAcY7DNNIwCU+Bz22ziTGlNUwmhUfneiXT2/Ih",
+	"WLNbCtH63lMLNXxX7rZGOd2G8nBfjNsCB2dNmDbGvKreJ3hlxStIqCAMUUzyYQgseEViXlUiMPIQROyt",
+	"1L9h0Br9BurtigoChaG/UucWU3ME8rEcUN/9q6ATZF/OTFDRFeISlRFfnLfNIKP76xYKGQy+hxq2XYJO",
+	"FdvBiLuufJ7BmP3/cAvD5BOCsVOme0W21gumwNN8HUTeIvM3DGC8H/8eAMzVYjrQBQAA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -0,0 +1,11 @@
+package http_v2
+
+import (
+	"testing"
+
+	"request_validator/spec/drift"
+)
+
+func TestSpecDoesNotDriftFromV1(t *testing.T) {
+	drift.Check(t, "../v1/api.yaml", "api.yaml")
+}
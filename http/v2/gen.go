@@ -1,5 +1,6 @@
 package http_v2
 
+//go:generate go run ../../cmd/spectags -in ../v1/api.yaml -out api.yaml
 //go:generate go install github.com/deepmap/oapi-codegen/v2/cmd/oapi-codegen@v2.2.0
 //go:generate oapi-codegen -version
 //go:generate oapi-codegen -config=gen.conf.yaml api.yaml
@@ -0,0 +1,13 @@
+package http_v2
+
+import (
+	"testing"
+
+	"request_validator/spec/stale"
+)
+
+func TestGeneratedCodeIsUpToDate(t *testing.T) {
+	stale.Check(t, "api.yaml", GetSwagger, stale.Structs{
+		"CreateUserReq": CreateUserReq{},
+	})
+}
@@ -0,0 +1,29 @@
+package drift
+
+// TestingT is the part of testing.TB used by Check.
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+	Logf(format string, args ...interface{})
+}
+
+// Check loads the plain and the tagged specs and fails the test with every drift between
+// them, logging the constraints that the Go validator can't enforce.
+//
+//	func TestSpecsDoNotDrift(t *testing.T) {
+//		drift.Check(t, "../v1/api.yaml", "api.yaml")
+//	}
+func Check(t TestingT, v1Path, v2Path string) {
+	t.Helper()
+	report, err := LoadFiles(v1Path, v2Path)
+	if err != nil {
+		t.Errorf("unable to compare the specs: %v", err)
+		return
+	}
+	for _, warning := range report.Unchecked {
+		t.Logf("unchecked: %s", warning)
+	}
+	for _, d := range report.Drifts {
+		t.Errorf("drift: %s", d)
+	}
+}
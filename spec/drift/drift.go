@@ -0,0 +1,318 @@
+// Package drift compares the plain OpenAPI spec used by the OpenAPI validator with the tagged
+// spec used by the Go validator, reporting the properties whose constraints differ so both
+// validators keep enforcing the same contract.
+package drift
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+
+	"request_validator/spec/tags"
+)
+
+// Kind is the kind of constraint that differs between the specs.
+type Kind string
+
+// The kinds of drift.
+const (
+	KindOperation Kind = "operation"
+	KindSchema    Kind = "schema"
+	KindProperty  Kind = "property"
+	KindRef       Kind = "ref"
+	KindTag       Kind = "tag"
+	KindRequired  Kind = "required"
+	KindOmitEmpty Kind = "omitempty"
+	KindFormat    Kind = "format"
+	KindEnum      Kind = "enum"
+	KindBounds    Kind = "bounds"
+	KindPattern   Kind = "pattern"
+	KindUnique    Kind = "unique"
+	KindItems     Kind = "items"
+)
+
+// Drift is a constraint that the validators of the specs enforce differently. V1 is the
+// constraint of the plain spec, expressed as the validate rules it should have, and V2 the
+// one of the tagged spec.
+type Drift struct {
+	// Path is the path of the property in the specs, such as
+	// "components.schemas.CreateUserReq.properties.id".
+	Path string
+	Kind Kind
+	V1   string
+	V2   string
+}
+
+func (d Drift) String() string {
+	return fmt.Sprintf("%s: the %s differs, v1 %q and v2 %q", d.Path, d.Kind, d.V1, d.V2)
+}
+
+// Report is the result of comparing the specs.
+type Report struct {
+	Drifts []Drift
+	// Unchecked are the constraints of the plain spec that can't be expressed as validate
+	// tags, so the Go validator can't enforce them whatever its spec says.
+	Unchecked []tags.Warning
+}
+
+// LoadFiles loads the plain and the tagged specs and compares them.
+func LoadFiles(v1Path, v2Path string) (*Report, error) {
+	v1, err := openapi3.NewLoader().LoadFromFile(v1Path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load the v1 spec: %w", err)
+	}
+	v2, err := openapi3.NewLoader().LoadFromFile(v2Path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load the v2 spec: %w", err)
+	}
+	return Compare(v1, v2), nil
+}
+
+// Compare compares the component schemas of the specs, the operations of their paths and the
+// inline schemas of the JSON request bodies of those operations. The schemas of the plain spec
+// are turned into the validate tags they should have and compared with the tags of the tagged
+// spec, kind by kind.
+func Compare(v1, v2 *openapi3.T) *Report {
+	c := &comparer{report: &Report{}}
+
+	var schemas1, schemas2 openapi3.Schemas
+	if v1.Components != nil {
+		schemas1 = v1.Components.Schemas
+	}
+	if v2.Components != nil {
+		schemas2 = v2.Components.Schemas
+	}
+	for _, name := range unionKeys(schemas1, schemas2) {
+		c.compareRefs("components.schemas."+name, schemas1[name], schemas2[name])
+	}
+
+	paths1, paths2 := v1.Paths.Map(), v2.Paths.Map()
+	for _, p := range unionKeys(paths1, paths2) {
+		var ops1, ops2 map[string]*openapi3.Operation
+		if item := paths1[p]; item != nil {
+			ops1 = item.Operations()
+		}
+		if item := paths2[p]; item != nil {
+			ops2 = item.Operations()
+		}
+		for _, method := range unionKeys(ops1, ops2) {
+			c.compareOperations("paths."+p+"."+strings.ToLower(method), ops1[method], ops2[method])
+		}
+	}
+	return c.report
+}
+
+type comparer struct {
+	report *Report
+}
+
+func (c *comparer) drift(path string, kind Kind, v1, v2 string) {
+	c.report.Drifts = append(c.report.Drifts, Drift{Path: path, Kind: kind, V1: v1, V2: v2})
+}
+
+func (c *comparer) compareOperations(path string, op1, op2 *openapi3.Operation) {
+	if op1 == nil || op2 == nil {
+		c.drift(path, KindOperation, presence(op1 != nil), presence(op2 != nil))
+		return
+	}
+	body1, body2 := jsonBody(op1), jsonBody(op2)
+	if body1 == nil && body2 == nil {
+		return
+	}
+	c.compareRefs(path+".requestBody", body1, body2)
+}
+
+// compareRefs compares two schemas of the same place in the specs. The $refs are compared by
+// the name of their schema, which is compared on its own in the components.
+func (c *comparer) compareRefs(path string, ref1, ref2 *openapi3.SchemaRef) {
+	if ref1 == nil || ref2 == nil {
+		c.drift(path, KindSchema, presence(ref1 != nil), presence(ref2 != nil))
+		return
+	}
+	if ref1.Ref != "" || ref2.Ref != "" {
+		if name1, name2 := refName(ref1), refName(ref2); name1 != name2 {
+			c.drift(path, KindRef, name1, name2)
+		}
+		return
+	}
+	c.compareProperties(path, ref1.Value, ref2.Value)
+}
+
+func (c *comparer) compareProperties(path string, schema1, schema2 *openapi3.Schema) {
+	if schema1 == nil || schema2 == nil {
+		return
+	}
+	required := make(map[string]bool, len(schema1.Required))
+	for _, name := range schema1.Required {
+		required[name] = true
+	}
+
+	for _, name := range unionKeys(schema1.Properties, schema2.Properties) {
+		propertyPath := path + ".properties." + name
+		property1, property2 := schema1.Properties[name], schema2.Properties[name]
+		if property1 == nil || property2 == nil {
+			c.drift(propertyPath, KindProperty, presence(property1 != nil), presence(property2 != nil))
+			continue
+		}
+		if property1.Ref != "" || property2.Ref != "" {
+			c.compareRefs(propertyPath, property1, property2)
+			continue
+		}
+		c.compareTags(propertyPath, property1.Value, required[name], property2.Value)
+
+		// the inline objects, and the inline objects of the arrays, have tags of their own
+		value1, value2 := property1.Value, property2.Value
+		if value1.Items != nil && value2.Items != nil && value1.Type.Is(openapi3.TypeArray) {
+			propertyPath += ".items"
+			if value1.Items.Ref != "" || value2.Items.Ref != "" {
+				c.compareRefs(propertyPath, value1.Items, value2.Items)
+				continue
+			}
+			value1, value2 = value1.Items.Value, value2.Items.Value
+		}
+		c.compareProperties(propertyPath, value1, value2)
+	}
+}
+
+// compareTags compares the tag the property of the plain spec should have with the validate
+// tag of the property of the tagged spec.
+func (c *comparer) compareTags(path string, schema1 *openapi3.Schema, required bool, schema2 *openapi3.Schema) {
+	want, warnings := tags.FromSchema(unresolvedItems(schema1), required)
+	for _, warning := range warnings {
+		c.report.Unchecked = append(c.report.Unchecked, tags.Warning{Path: path, Message: warning})
+	}
+	tag := validateTag(schema2)
+	got, err := tags.Parse(tag)
+	if err != nil {
+		c.drift(path, KindTag, want.String(), tag)
+		return
+	}
+
+	if want.Required != got.Required {
+		c.drift(path, KindRequired, fmt.Sprint(want.Required), fmt.Sprint(got.Required))
+	} else if !want.Required && want.OmitEmpty != got.OmitEmpty {
+		// without omitempty the rules of an optional field fail when it is absent
+		c.drift(path, KindOmitEmpty, fmt.Sprint(want.OmitEmpty), fmt.Sprint(got.OmitEmpty))
+	}
+	c.compareRules(path, want.Rules, got.Rules)
+	if want.Dive != got.Dive {
+		c.drift(path, KindItems, diveString(want), diveString(got))
+		return
+	}
+	if want.Dive {
+		c.compareRules(path+".items", itemRules(want), itemRules(got))
+	}
+}
+
+// compareRules compares the rules of each kind as sets, so their order doesn't matter.
+func (c *comparer) compareRules(path string, want, got []tags.Rule) {
+	wantKinds, gotKinds := ruleKinds(want), ruleKinds(got)
+	for _, kind := range unionKeys(wantKinds, gotKinds) {
+		if w, g := strings.Join(wantKinds[kind], ","), strings.Join(gotKinds[kind], ","); w != g {
+			c.drift(path, kind, w, g)
+		}
+	}
+}
+
+// ruleKinds groups the rules by kind, sorted. The rules of unknown tags, such as alpha, are
+// grouped with the formats as they restrict the shape of the strings.
+func ruleKinds(rules []tags.Rule) map[Kind][]string {
+	kinds := make(map[Kind][]string)
+	for _, rule := range rules {
+		kind := KindFormat
+		switch rule.Tag {
+		case "oneof":
+			// the values of an enum are compared as a set too
+			values := strings.Fields(rule.Param)
+			sort.Strings(values)
+			rule.Param = strings.Join(values, " ")
+			kind = KindEnum
+		case "min", "max", "len", "gt", "gte", "lt", "lte", "eq", "ne":
+			kind = KindBounds
+		case "pattern":
+			kind = KindPattern
+		case "unique":
+			kind = KindUnique
+		}
+		kinds[kind] = append(kinds[kind], rule.String())
+	}
+	for _, values := range kinds {
+		sort.Strings(values)
+	}
+	return kinds
+}
+
+func validateTag(schema *openapi3.Schema) string {
+	extraTags, _ := schema.Extensions[tags.ExtraTagsExtension].(map[string]interface{})
+	tag, _ := extraTags["validate"].(string)
+	return tag
+}
+
+// unresolvedItems drops the schema of the $ref items, which the loader resolves, so the tag
+// is derived as spectags does from the document, diving into the referenced schema.
+func unresolvedItems(schema *openapi3.Schema) *openapi3.Schema {
+	if schema.Items == nil || schema.Items.Ref == "" {
+		return schema
+	}
+	unresolved := *schema
+	unresolved.Items = &openapi3.SchemaRef{Ref: schema.Items.Ref}
+	return &unresolved
+}
+
+func jsonBody(op *openapi3.Operation) *openapi3.SchemaRef {
+	if op.RequestBody == nil || op.RequestBody.Value == nil {
+		return nil
+	}
+	media := op.RequestBody.Value.Content.Get("application/json")
+	if media == nil {
+		return nil
+	}
+	return media.Schema
+}
+
+func refName(ref *openapi3.SchemaRef) string {
+	if ref.Ref == "" {
+		return "inline schema"
+	}
+	return path.Base(ref.Ref)
+}
+
+func diveString(tag *tags.Tag) string {
+	if !tag.Dive {
+		return "no dive"
+	}
+	return "dive"
+}
+
+func itemRules(tag *tags.Tag) []tags.Rule {
+	if tag.Items == nil {
+		return nil
+	}
+	return tag.Items.Rules
+}
+
+func presence(present bool) string {
+	if present {
+		return "present"
+	}
+	return "missing"
+}
+
+// unionKeys returns the sorted keys of both maps.
+func unionKeys[K ~string, V any](a, b map[K]V) []K {
+	seen := make(map[K]bool, len(a)+len(b))
+	keys := make([]K, 0, len(a)+len(b))
+	for _, m := range []map[K]V{a, b} {
+		for key := range m {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
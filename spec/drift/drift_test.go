@@ -0,0 +1,304 @@
+package drift
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/require"
+
+	"request_validator/spec/tags"
+)
+
+const plainSpec = `openapi: 3.0.0
+info:
+  title: Guilds
+  version: 1.0.0
+paths:
+  /guilds:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/CreateGuildReq'
+      responses:
+        '200':
+          description: ok
+components:
+  schemas:
+    CreateGuildReq:
+      type: object
+      required: [id, name]
+      properties:
+        id:
+          type: string
+          format: uuid
+        name:
+          type: string
+          minLength: 3
+          maxLength: 20
+        region:
+          type: string
+          enum: [eu, us]
+        size:
+          type: integer
+          multipleOf: 5
+        members:
+          type: array
+          minItems: 1
+          items:
+            type: object
+            required: [email]
+            properties:
+              email:
+                type: string
+                format: email
+`
+
+func loadSpec(t *testing.T, spec string) *openapi3.T {
+	t.Helper()
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(spec))
+	require.NoError(t, err, "the spec should load")
+	return doc
+}
+
+func taggedSpec(t *testing.T, src string) string {
+	t.Helper()
+	tagged, _, err := tags.Transform([]byte(src))
+	require.NoError(t, err, "transform should not error")
+	return string(tagged)
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name       string
+		tagged     func(spec string) string
+		wantDrifts []Drift
+	}{
+		{
+			name:   "given a spec tagged by spectags, when we compare it, it should not drift",
+			tagged: func(spec string) string { return spec },
+		},
+		{
+			name: "given a required field that is optional in the tagged spec, when we compare it, the required-ness should drift",
+			tagged: func(spec string) string {
+				return replace(spec, "validate: required,min=3,max=20", "validate: omitempty,min=3,max=20")
+			},
+			wantDrifts: []Drift{{Path: "components.schemas.CreateGuildReq.properties.name", Kind: KindRequired, V1: "true", V2: "false"}},
+		},
+		{
+			name: "given a format without its tag, when we compare it, the format should drift",
+			tagged: func(spec string) string {
+				return replace(spec, "validate: required,uuid", "validate: required")
+			},
+			wantDrifts: []Drift{{Path: "components.schemas.CreateGuildReq.properties.id", Kind: KindFormat, V1: "uuid", V2: ""}},
+		},
+		{
+			name: "given an enum with another value and its values reordered, when we compare it, only the values should drift",
+			tagged: func(spec string) string {
+				return replace(spec, "validate: omitempty,oneof=eu us", "validate: omitempty,oneof=us eu asia")
+			},
+			wantDrifts: []Drift{{Path: "components.schemas.CreateGuildReq.properties.region", Kind: KindEnum, V1: "oneof=eu us", V2: "oneof=asia eu us"}},
+		},
+		{
+			name: "given different bounds, when we compare them, the bounds should drift",
+			tagged: func(spec string) string {
+				return replace(spec, "validate: required,min=3,max=20", "validate: required,max=30,min=3")
+			},
+			wantDrifts: []Drift{{Path: "components.schemas.CreateGuildReq.properties.name", Kind: KindBounds, V1: "max=20,min=3", V2: "max=30,min=3"}},
+		},
+		{
+			name: "given a nested property without its tag, when we compare it, the items property should drift",
+			tagged: func(spec string) string {
+				return replace(spec, "validate: required,email", "validate: required")
+			},
+			wantDrifts: []Drift{{Path: "components.schemas.CreateGuildReq.properties.members.items.properties.email", Kind: KindFormat, V1: "email", V2: ""}},
+		},
+		{
+			name: "given an array that doesn't dive, when we compare it, the items should drift",
+			tagged: func(spec string) string {
+				return replace(spec, "validate: omitempty,min=1,dive", "validate: omitempty,min=1")
+			},
+			wantDrifts: []Drift{{Path: "components.schemas.CreateGuildReq.properties.members", Kind: KindItems, V1: "dive", V2: "no dive"}},
+		},
+		{
+			name: "given a property and an operation missing from the tagged spec, when we compare it, both should drift",
+			tagged: func(spec string) string {
+				spec = replace(spec, "  /guilds:\n    post:", "  /teams:\n    post:")
+				return replace(spec, "        region:\n", "        zone:\n")
+			},
+			wantDrifts: []Drift{
+				{Path: "components.schemas.CreateGuildReq.properties.region", Kind: KindProperty, V1: "present", V2: "missing"},
+				{Path: "components.schemas.CreateGuildReq.properties.zone", Kind: KindProperty, V1: "missing", V2: "present"},
+				{Path: "paths./guilds.post", Kind: KindOperation, V1: "present", V2: "missing"},
+				{Path: "paths./teams.post", Kind: KindOperation, V1: "missing", V2: "present"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			v1 := loadSpec(t, plainSpec)
+			v2 := loadSpec(t, tt.tagged(taggedSpec(t, plainSpec)))
+
+			// act
+			report := Compare(v1, v2)
+
+			// assert
+			require.Equal(t, tt.wantDrifts, report.Drifts)
+			require.Equal(t, []tags.Warning{{
+				Path:    "components.schemas.CreateGuildReq.properties.size",
+				Message: "the multipleOf constraint has no validate tag",
+			}}, report.Unchecked)
+		})
+	}
+}
+
+// handSpec is the plain spec with its validate tags written by hand, so the comparison is not
+// only checked against the tags derived by FromSchema.
+const handSpec = `openapi: 3.0.0
+info:
+  title: Users
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    CreateUserReq:
+      type: object
+      required: [id, name]
+      properties:
+        id:
+          type: string
+          format: uuid
+          %s
+        name:
+          type: string
+          minLength: 3
+          %s
+        email:
+          type: string
+          format: email
+          %s
+`
+
+func handTags(id, name, email string) string {
+	tag := func(validate string) string {
+		if validate == "" {
+			return ""
+		}
+		return "x-oapi-codegen-extra-tags: {validate: '" + validate + "'}"
+	}
+	return fmt.Sprintf(handSpec, tag(id), tag(name), tag(email))
+}
+
+func TestCompareHandWrittenTags(t *testing.T) {
+	const email = "components.schemas.CreateUserReq.properties.email"
+
+	tests := []struct {
+		name       string
+		v2         string
+		wantDrifts []Drift
+	}{
+		{
+			name: "given tags written by hand for every constraint, when we compare them, they should not drift",
+			v2:   handTags("required,uuid", "required,min=3", "omitempty,email"),
+		},
+		{
+			name:       "given an optional format without omitempty, when we compare it, the omitempty should drift as the go validator rejects the absent field",
+			v2:         handTags("required,uuid", "required,min=3", "email"),
+			wantDrifts: []Drift{{Path: email, Kind: KindOmitEmpty, V1: "true", V2: "false"}},
+		},
+		{
+			name: "given the uuid tag of go-playground instead of the one of the formats library, when we compare them, the format should drift",
+			v2:   handTags("required,uuid_rfc4122", "required,min=3", "omitempty,email"),
+			wantDrifts: []Drift{
+				{Path: "components.schemas.CreateUserReq.properties.id", Kind: KindFormat, V1: "uuid", V2: "uuid_rfc4122"},
+			},
+		},
+		{
+			name: "given a uuid tag that only accepts the version 4 and a maximum length instead of a minimum one, when we compare them, both should drift",
+			v2:   handTags("required,uuid4", "required,max=3", "omitempty,email"),
+			wantDrifts: []Drift{
+				{Path: "components.schemas.CreateUserReq.properties.id", Kind: KindFormat, V1: "uuid", V2: "uuid4"},
+				{Path: "components.schemas.CreateUserReq.properties.name", Kind: KindBounds, V1: "min=3", V2: "max=3"},
+			},
+		},
+		{
+			name: "given no tags at all, when we compare them, every constraint should drift",
+			v2:   handTags("", "", ""),
+			wantDrifts: []Drift{
+				{Path: email, Kind: KindOmitEmpty, V1: "true", V2: "false"},
+				{Path: email, Kind: KindFormat, V1: "email", V2: ""},
+				{Path: "components.schemas.CreateUserReq.properties.id", Kind: KindRequired, V1: "true", V2: "false"},
+				{Path: "components.schemas.CreateUserReq.properties.id", Kind: KindFormat, V1: "uuid", V2: ""},
+				{Path: "components.schemas.CreateUserReq.properties.name", Kind: KindRequired, V1: "true", V2: "false"},
+				{Path: "components.schemas.CreateUserReq.properties.name", Kind: KindBounds, V1: "min=3", V2: ""},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			v1 := loadSpec(t, handTags("", "", ""))
+			v2 := loadSpec(t, tt.v2)
+
+			// act
+			report := Compare(v1, v2)
+
+			// assert
+			require.Equal(t, tt.wantDrifts, report.Drifts)
+		})
+	}
+}
+
+type recorder struct {
+	errors []string
+	logs   []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) Logf(format string, args ...interface{}) {
+	r.logs = append(r.logs, fmt.Sprintf(format, args...))
+}
+
+func TestCheck(t *testing.T) {
+	// arrange
+	rec := &recorder{}
+
+	// act
+	Check(rec, "../../http/v1/api.yaml", "../../http/v1/api.yaml")
+
+	// assert
+	require.Equal(t, []string{
+		`drift: components.schemas.CreateUserReq.properties.email: the omitempty differs, v1 "true" and v2 "false"`,
+		`drift: components.schemas.CreateUserReq.properties.email: the format differs, v1 "email" and v2 ""`,
+		`drift: components.schemas.CreateUserReq.properties.firstName: the required differs, v1 "true" and v2 "false"`,
+		`drift: components.schemas.CreateUserReq.properties.id: the required differs, v1 "true" and v2 "false"`,
+		`drift: components.schemas.CreateUserReq.properties.id: the format differs, v1 "uuid" and v2 ""`,
+		`drift: components.schemas.CreateUserReq.properties.lastName: the required differs, v1 "true" and v2 "false"`,
+	}, rec.errors)
+}
+
+func TestCheckMissingSpec(t *testing.T) {
+	// arrange
+	rec := &recorder{}
+
+	// act
+	Check(rec, "missing.yaml", "../../http/v2/api.yaml")
+
+	// assert
+	require.Len(t, rec.errors, 1)
+}
+
+func replace(s, old, new string) string {
+	return strings.Replace(s, old, new, 1)
+}
@@ -0,0 +1,161 @@
+// Package loader loads OpenAPI documents split into several files, from the disk or from a
+// file system such as an embed.FS, resolving the relative $refs between the files, so the
+// specs can be handed to the validators without generating code.
+package loader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+var (
+	// ErrRemoteRef is returned for the $refs to remote documents unless they are allowed
+	// with WithRemoteRefs.
+	ErrRemoteRef = errors.New("remote $refs are not allowed")
+	// ErrRootNotFound is returned when a directory has none of the root documents.
+	ErrRootNotFound = errors.New("root document not found")
+)
+
+// RootNames are the names of the root document looked up in the directories, in order.
+var RootNames = []string{"openapi.yaml", "openapi.yml", "openapi.json", "api.yaml", "api.yml", "api.json"}
+
+// Option configures the loading of a document.
+type Option func(*options)
+
+type options struct {
+	remoteClient      *http.Client
+	skipValidation    bool
+	validationOptions []openapi3.ValidationOption
+}
+
+// WithRemoteRefs allows the $refs to documents served over HTTP, which are read with the
+// given client, or the default one if it is nil.
+func WithRemoteRefs(client *http.Client) Option {
+	return func(o *options) {
+		if client == nil {
+			client = http.DefaultClient
+		}
+		o.remoteClient = client
+	}
+}
+
+// WithValidationOptions sets the options of the validation of the loaded document.
+func WithValidationOptions(opts ...openapi3.ValidationOption) Option {
+	return func(o *options) {
+		o.validationOptions = append(o.validationOptions, opts...)
+	}
+}
+
+// WithoutValidation skips the validation of the loaded document, such as when it is
+// validated later by the validator it is handed to.
+func WithoutValidation() Option {
+	return func(o *options) {
+		o.skipValidation = true
+	}
+}
+
+// FromFile loads the document of the file at the given path, or of the root document of the
+// directory at the given path. The relative $refs are resolved from the directory of the
+// file that holds them and may point outside of it.
+func FromFile(ctx context.Context, name string, opts ...Option) (*openapi3.T, error) {
+	info, err := os.Stat(name)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load the spec: %w", err)
+	}
+	if info.IsDir() {
+		return FromDir(ctx, name, opts...)
+	}
+	return load(ctx, filepath.ToSlash(name), openapi3.ReadFromFile, opts)
+}
+
+// FromDir loads the root document of the directory, the first of RootNames it has.
+func FromDir(ctx context.Context, dir string, opts ...Option) (*openapi3.T, error) {
+	root, err := findRoot(os.DirFS(dir), ".")
+	if err != nil {
+		return nil, fmt.Errorf("unable to load the spec of %s: %w", dir, err)
+	}
+	return load(ctx, filepath.ToSlash(filepath.Join(dir, root)), openapi3.ReadFromFile, opts)
+}
+
+// FromFS loads the document of the file with the given name in the file system, or of the
+// root document of the directory with the given name, such as "." for the whole file system.
+// The relative $refs are resolved within the file system, which they can't leave.
+func FromFS(ctx context.Context, fsys fs.FS, name string, opts ...Option) (*openapi3.T, error) {
+	info, err := fs.Stat(fsys, name)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load the spec: %w", err)
+	}
+	if info.IsDir() {
+		root, err := findRoot(fsys, name)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load the spec of %s: %w", name, err)
+		}
+		name = path.Join(name, root)
+	}
+	return load(ctx, name, readFromFS(fsys), opts)
+}
+
+func load(ctx context.Context, location string, readLocal openapi3.ReadFromURIFunc, opts []Option) (*openapi3.T, error) {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	loader := openapi3.NewLoader()
+	loader.Context = ctx
+	loader.IsExternalRefsAllowed = true
+	loader.ReadFromURIFunc = func(loader *openapi3.Loader, location *url.URL) ([]byte, error) {
+		if location.Scheme == "http" || location.Scheme == "https" {
+			if o.remoteClient == nil {
+				return nil, fmt.Errorf("%w: %s", ErrRemoteRef, location)
+			}
+			return openapi3.ReadFromHTTP(o.remoteClient)(loader, location)
+		}
+		return readLocal(loader, location)
+	}
+
+	doc, err := loader.LoadFromURI(&url.URL{Path: location})
+	if err != nil {
+		return nil, fmt.Errorf("unable to load the spec %s: %w", location, err)
+	}
+	if !o.skipValidation {
+		if err := doc.Validate(ctx, o.validationOptions...); err != nil {
+			return nil, fmt.Errorf("unable to validate the spec %s: %w", location, err)
+		}
+	}
+	return doc, nil
+}
+
+// readFromFS reads the local documents from the file system.
+func readFromFS(fsys fs.FS) openapi3.ReadFromURIFunc {
+	return func(_ *openapi3.Loader, location *url.URL) ([]byte, error) {
+		if location.Host != "" || (location.Scheme != "" && location.Scheme != "file") {
+			return nil, openapi3.ErrURINotSupported
+		}
+		name := path.Clean(strings.TrimPrefix(location.Path, "/"))
+		if !fs.ValidPath(name) {
+			return nil, fmt.Errorf("the document %s is outside of the file system", location.Path)
+		}
+		return fs.ReadFile(fsys, name)
+	}
+}
+
+func findRoot(fsys fs.FS, dir string) (string, error) {
+	for _, name := range RootNames {
+		info, err := fs.Stat(fsys, path.Join(dir, name))
+		if err == nil && !info.IsDir() {
+			return name, nil
+		}
+	}
+	return "", fmt.Errorf("%w, expected one of %s", ErrRootNotFound, strings.Join(RootNames, ", "))
+}
@@ -0,0 +1,234 @@
+package loader
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"errors"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/require"
+
+	kinvalidator "request_validator/validator/kin_validator"
+)
+
+//go:embed testdata
+var testdata embed.FS
+
+const remoteSpec = `openapi: 3.0.0
+info:
+  title: Users
+  version: 1.0.0
+paths:
+  /users/create:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '{{url}}/user.yaml#/CreateUserReq'
+      responses:
+        '200':
+          description: ok
+`
+
+func requireUserSchema(t *testing.T, doc *openapi3.T) {
+	t.Helper()
+	op := doc.Paths.Value("/users/create").Post
+	schema := op.RequestBody.Value.Content.Get("application/json").Schema.Value
+	require.NotNil(t, schema, "the $ref to the other file should be resolved")
+	require.Equal(t, []string{"id", "email"}, schema.Required)
+	email := schema.Properties["email"].Value
+	require.NotNil(t, email, "the $ref of the other file should be resolved from its directory")
+	require.Equal(t, "email", email.Format)
+}
+
+func TestLoad(t *testing.T) {
+	ctx := context.Background()
+	split, err := fs.Sub(testdata, "testdata/split")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		load func() (*openapi3.T, error)
+	}{
+		{
+			name: "given the root document of a split spec, when we load its file, every $ref should be resolved",
+			load: func() (*openapi3.T, error) { return FromFile(ctx, "testdata/split/openapi.yaml") },
+		},
+		{
+			name: "given the directory of a split spec, when we load it, its root document should be loaded",
+			load: func() (*openapi3.T, error) { return FromDir(ctx, "testdata/split") },
+		},
+		{
+			name: "given the directory of a split spec, when we load it as a file, its root document should be loaded",
+			load: func() (*openapi3.T, error) { return FromFile(ctx, "testdata/split") },
+		},
+		{
+			name: "given a root document that refers to a file outside of its directory, when we load it from the disk, the $ref should be resolved",
+			load: func() (*openapi3.T, error) { return FromFile(ctx, "testdata/escape/openapi.yaml") },
+		},
+		{
+			name: "given a split spec in an embed.FS, when we load its root document, every $ref should be resolved",
+			load: func() (*openapi3.T, error) { return FromFS(ctx, testdata, "testdata/split/openapi.yaml") },
+		},
+		{
+			name: "given a split spec at the root of a file system, when we load the whole file system, its root document should be loaded",
+			load: func() (*openapi3.T, error) { return FromFS(ctx, split, ".") },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// act
+			doc, err := tt.load()
+
+			// assert
+			require.NoError(t, err, "load should not error")
+			requireUserSchema(t, doc)
+		})
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	ctx := context.Background()
+	escape, err := fs.Sub(testdata, "testdata/escape")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		load    func() (*openapi3.T, error)
+		wantErr error
+	}{
+		{
+			name:    "given a directory without a root document, when we load it, a root not found error should be returned",
+			load:    func() (*openapi3.T, error) { return FromDir(ctx, "testdata/split/schemas") },
+			wantErr: ErrRootNotFound,
+		},
+		{
+			name: "given a document that refers to a file outside of the file system, when we load it, an error should be returned",
+			load: func() (*openapi3.T, error) { return FromFS(ctx, escape, "openapi.yaml") },
+		},
+		{
+			name:    "given a missing file, when we load it, a not exist error should be returned",
+			load:    func() (*openapi3.T, error) { return FromFile(ctx, "testdata/missing.yaml") },
+			wantErr: fs.ErrNotExist,
+		},
+		{
+			name: "given a document with a remote $ref, when we load it without allowing them, an error should be returned",
+			load: func() (*openapi3.T, error) {
+				fsys := fstest.MapFS{"openapi.yaml": {Data: bytes.ReplaceAll([]byte(remoteSpec), []byte("{{url}}"), []byte("http://example.com"))}}
+				return FromFS(ctx, fsys, "openapi.yaml")
+			},
+			wantErr: ErrRemoteRef,
+		},
+		{
+			name: "given an invalid document, when we load it, a validation error should be returned",
+			load: func() (*openapi3.T, error) {
+				fsys := fstest.MapFS{"openapi.yaml": {Data: []byte("openapi: 3.0.0\ninfo:\n  title: Users\npaths: {}\n")}}
+				return FromFS(ctx, fsys, "openapi.yaml")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// act
+			doc, err := tt.load()
+
+			// assert
+			require.Error(t, err, "load should error")
+			require.Nil(t, doc)
+			if tt.wantErr != nil {
+				require.True(t, errors.Is(err, tt.wantErr), "error should be %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestLoadWithoutValidation(t *testing.T) {
+	// arrange
+	fsys := fstest.MapFS{"openapi.yaml": {Data: []byte("openapi: 3.0.0\ninfo:\n  title: Users\npaths: {}\n")}}
+
+	// act
+	doc, err := FromFS(context.Background(), fsys, "openapi.yaml", WithoutValidation())
+
+	// assert
+	require.NoError(t, err, "load should not error")
+	require.Equal(t, "Users", doc.Info.Title)
+}
+
+func TestLoadWithRemoteRefs(t *testing.T) {
+	// arrange
+	user, err := testdata.ReadFile("testdata/split/schemas/user.yaml")
+	require.NoError(t, err)
+	contact, err := testdata.ReadFile("testdata/split/schemas/contact.yaml")
+	require.NoError(t, err)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/user.yaml":
+			_, _ = w.Write(user)
+		case "/contact.yaml":
+			_, _ = w.Write(contact)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	fsys := fstest.MapFS{"openapi.yaml": {Data: bytes.ReplaceAll([]byte(remoteSpec), []byte("{{url}}"), []byte(server.URL))}}
+
+	// act
+	doc, err := FromFS(context.Background(), fsys, "openapi.yaml", WithRemoteRefs(server.Client()))
+
+	// assert
+	require.NoError(t, err, "load should not error")
+	requireUserSchema(t, doc)
+}
+
+func TestLoadedSpecValidatesRequests(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	doc, err := FromFS(ctx, testdata, "testdata/split")
+	require.NoError(t, err, "load should not error")
+	v, err := kinvalidator.NewValidator(ctx, doc)
+	require.NoError(t, err, "the validator should be created")
+
+	tests := []struct {
+		name    string
+		body    string
+		wantErr bool
+	}{
+		{
+			name: "given a valid request, when we validate it against the split spec, no error should be returned",
+			body: `{"id": "32d3e8f1-2f81-49c0-acb6-6dccd84f3dab", "email": "batman@gotham.com"}`,
+		},
+		{
+			name:    "given a request that breaks a schema of another file, when we validate it against the split spec, an error should be returned",
+			body:    `{"id": "32d3e8f1-2f81-49c0-acb6-6dccd84f3dab", "email": "batman"}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			req := httptest.NewRequest(http.MethodPost, "http://api.example.com/v1/users/create", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			// act
+			err := v.ValidateRequest(ctx, req)
+
+			// assert
+			if tt.wantErr {
+				require.Error(t, err, "validator should error")
+				return
+			}
+			require.NoError(t, err, "validator should not error")
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package stale
+
+import "github.com/getkin/kin-openapi/openapi3"
+
+// TestingT is the part of testing.TB used by Check.
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}
+
+// Check fails the test with every difference between the document of the file and the code
+// generated from it, the spec returned by its GetSwagger and its structs.
+//
+//	func TestGeneratedCodeIsUpToDate(t *testing.T) {
+//		stale.Check(t, "api.yaml", GetSwagger, stale.Structs{"CreateUserReq": CreateUserReq{}})
+//	}
+func Check(t TestingT, specPath string, getSwagger func() (*openapi3.T, error), structs Structs) {
+	t.Helper()
+	doc, err := LoadFile(specPath)
+	if err != nil {
+		t.Errorf("%v", err)
+		return
+	}
+	embedded, err := getSwagger()
+	if err != nil {
+		t.Errorf("unable to decode the embedded spec: %v", err)
+		return
+	}
+
+	differences, err := CompareEmbedded(doc, embedded)
+	if err != nil {
+		t.Errorf("%v", err)
+		return
+	}
+	differences = append(differences, CompareStructs(doc, structs)...)
+	for _, d := range differences {
+		t.Errorf("stale: %s, run go generate", d)
+	}
+}
@@ -0,0 +1,157 @@
+// Package stale checks that the code generated by oapi-codegen is up to date with the OpenAPI
+// document it was generated from: the spec embedded by GetSwagger and the Go structs of the
+// component schemas.
+package stale
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Difference is a difference between the document and the generated code.
+type Difference struct {
+	// Path is the path of the difference in the document, such as
+	// "components.schemas.CreateUserReq.properties.id".
+	Path    string
+	Message string
+}
+
+func (d Difference) String() string {
+	return fmt.Sprintf("%s: %s", d.Path, d.Message)
+}
+
+// LoadFile loads the document of a file, without validating it as the generated code embeds
+// whatever the document holds.
+func LoadFile(path string) (*openapi3.T, error) {
+	doc, err := openapi3.NewLoader().LoadFromFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load the %s spec: %w", path, err)
+	}
+	return doc, nil
+}
+
+// CompareEmbedded compares the document with the spec embedded in the generated code, as it
+// would be seen by the validators, so the formatting and the comments don't matter. The
+// operationIds that are only in the embedded spec are ignored, as oapi-codegen generates them
+// for the operations without one.
+func CompareEmbedded(doc, embedded *openapi3.T) ([]Difference, error) {
+	want, err := toJSONValue(doc)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode the spec: %w", err)
+	}
+	got, err := toJSONValue(embedded)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode the embedded spec: %w", err)
+	}
+	dropGeneratedOperationIDs(want, got)
+
+	var differences []Difference
+	diffValues("", want, got, &differences)
+	return differences, nil
+}
+
+func toJSONValue(doc *openapi3.T) (interface{}, error) {
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+func dropGeneratedOperationIDs(want, got interface{}) {
+	wantPaths, _ := mapAt(want, "paths")
+	gotPaths, _ := mapAt(got, "paths")
+	for p, item := range gotPaths {
+		gotItem, _ := item.(map[string]interface{})
+		wantItem, _ := wantPaths[p].(map[string]interface{})
+		for method, op := range gotItem {
+			gotOp, _ := op.(map[string]interface{})
+			wantOp, _ := wantItem[method].(map[string]interface{})
+			if gotOp == nil || wantOp == nil {
+				continue
+			}
+			if _, ok := wantOp["operationId"]; !ok {
+				delete(gotOp, "operationId")
+			}
+		}
+	}
+}
+
+func mapAt(value interface{}, key string) (map[string]interface{}, bool) {
+	m, _ := value.(map[string]interface{})
+	child, ok := m[key].(map[string]interface{})
+	return child, ok
+}
+
+// diffValues appends the differences between the JSON values of the document and of the
+// embedded spec.
+func diffValues(path string, want, got interface{}, differences *[]Difference) {
+	wantMap, wantIsMap := want.(map[string]interface{})
+	gotMap, gotIsMap := got.(map[string]interface{})
+	if wantIsMap && gotIsMap {
+		keys := make([]string, 0, len(wantMap)+len(gotMap))
+		for key := range wantMap {
+			keys = append(keys, key)
+		}
+		for key := range gotMap {
+			if _, ok := wantMap[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			wantValue, inWant := wantMap[key]
+			gotValue, inGot := gotMap[key]
+			switch {
+			case !inGot:
+				*differences = append(*differences, Difference{Path: join(path, key), Message: "missing from the embedded spec"})
+			case !inWant:
+				*differences = append(*differences, Difference{Path: join(path, key), Message: "only in the embedded spec"})
+			default:
+				diffValues(join(path, key), wantValue, gotValue, differences)
+			}
+		}
+		return
+	}
+
+	wantSlice, wantIsSlice := want.([]interface{})
+	gotSlice, gotIsSlice := got.([]interface{})
+	if wantIsSlice && gotIsSlice && len(wantSlice) == len(gotSlice) {
+		for i := range wantSlice {
+			diffValues(join(path, strconv.Itoa(i)), wantSlice[i], gotSlice[i], differences)
+		}
+		return
+	}
+
+	if !reflect.DeepEqual(want, got) {
+		*differences = append(*differences, Difference{
+			Path:    path,
+			Message: fmt.Sprintf("the spec has %s and the embedded spec %s", encode(want), encode(got)),
+		})
+	}
+}
+
+func join(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func encode(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
@@ -0,0 +1,134 @@
+package stale
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/require"
+)
+
+const guildsSpec = `openapi: 3.0.0
+info:
+  title: Guilds
+  version: 1.0.0
+paths:
+  /guilds:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/CreateGuildReq'
+      responses:
+        '200':
+          description: ok
+components:
+  schemas:
+    CreateGuildReq:
+      type: object
+      required: [id, name]
+      properties:
+        id:
+          type: string
+          format: uuid
+        name:
+          type: string
+          x-oapi-codegen-extra-tags:
+            validate: required,min=3
+        size:
+          type: integer
+          format: int32
+        tags:
+          type: array
+          items:
+            type: string
+        leader:
+          $ref: '#/components/schemas/Member'
+    Member:
+      type: object
+      required: [email]
+      properties:
+        email:
+          type: string
+          format: email
+`
+
+func loadSpec(t *testing.T, spec string) *openapi3.T {
+	t.Helper()
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(spec))
+	require.NoError(t, err, "the spec should load")
+	return doc
+}
+
+func TestCompareEmbedded(t *testing.T) {
+	tests := []struct {
+		name     string
+		embedded string
+		want     []Difference
+	}{
+		{
+			name:     "given an embedded spec with a generated operationId, when we compare it, it should not differ",
+			embedded: strings.Replace(guildsSpec, "    post:\n", "    post:\n      operationId: PostGuilds\n", 1),
+		},
+		{
+			name:     "given an embedded spec with the formatting changed, when we compare it, it should not differ",
+			embedded: strings.Replace(guildsSpec, "required: [id, name]", "required:\n        - id\n        - name", 1),
+		},
+		{
+			name:     "given an embedded spec generated before a change of the required properties, when we compare it, the required list should differ",
+			embedded: strings.Replace(guildsSpec, "required: [id, name]", "required: [id]", 1),
+			want: []Difference{{
+				Path:    "components.schemas.CreateGuildReq.required",
+				Message: `the spec has ["id","name"] and the embedded spec ["id"]`,
+			}},
+		},
+		{
+			name:     "given an embedded spec generated before a property was added, when we compare it, the property should be missing",
+			embedded: strings.Replace(guildsSpec, "        size:\n          type: integer\n          format: int32\n", "", 1),
+			want: []Difference{{
+				Path:    "components.schemas.CreateGuildReq.properties.size",
+				Message: "missing from the embedded spec",
+			}},
+		},
+		{
+			name:     "given an embedded spec generated before a tag changed, when we compare it, the tag should differ",
+			embedded: strings.Replace(guildsSpec, "validate: required,min=3", "validate: required", 1),
+			want: []Difference{{
+				Path:    "components.schemas.CreateGuildReq.properties.name.x-oapi-codegen-extra-tags.validate",
+				Message: `the spec has "required,min=3" and the embedded spec "required"`,
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			doc, embedded := loadSpec(t, guildsSpec), loadSpec(t, tt.embedded)
+
+			// act
+			differences, err := CompareEmbedded(doc, embedded)
+
+			// assert
+			require.NoError(t, err, "compare should not error")
+			require.Equal(t, tt.want, differences)
+		})
+	}
+}
+
+func TestCompareEmbeddedOnlyInEmbeddedSpec(t *testing.T) {
+	// arrange
+	doc := loadSpec(t, guildsSpec)
+	embedded := loadSpec(t, strings.Replace(guildsSpec, "    post:\n", "    post:\n      operationId: PostGuilds\n", 1))
+	doc.Paths.Value("/guilds").Post.OperationID = "CreateGuild"
+
+	// act
+	differences, err := CompareEmbedded(doc, embedded)
+
+	// assert
+	require.NoError(t, err, "compare should not error")
+	require.Equal(t, []Difference{{
+		Path:    "paths./guilds.post.operationId",
+		Message: `the spec has "CreateGuild" and the embedded spec "PostGuilds"`,
+	}}, differences)
+}
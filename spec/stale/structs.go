@@ -0,0 +1,239 @@
+package stale
+
+import (
+	"fmt"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+
+	"request_validator/spec/tags"
+)
+
+// Structs are the generated structs of the component schemas by the name of their schema,
+// such as {"CreateUserReq": http_v1.CreateUserReq{}}.
+type Structs map[string]interface{}
+
+// formatTypes are the names of the Go types that oapi-codegen generates for the string
+// formats, the other strings are generated as string.
+var formatTypes = map[string]string{
+	"uuid":      "UUID",
+	"email":     "Email",
+	"date":      "Date",
+	"date-time": "Time",
+	"binary":    "File",
+}
+
+// numberKinds are the kinds of the numbers with a format, the other integers are generated
+// as int and the other numbers as float64.
+var numberKinds = map[string]reflect.Kind{
+	"int32":  reflect.Int32,
+	"int64":  reflect.Int64,
+	"float":  reflect.Float32,
+	"double": reflect.Float64,
+}
+
+// CompareStructs compares the object schemas of the components of the document with their
+// generated structs: every property should have a field with its json name, a pointer unless
+// it is required, of the type of its schema and with the extra tags of the schema.
+func CompareStructs(doc *openapi3.T, structs Structs) []Difference {
+	c := &structComparer{}
+
+	var schemas openapi3.Schemas
+	if doc.Components != nil {
+		schemas = doc.Components.Schemas
+	}
+	names := make([]string, 0, len(schemas))
+	for name := range schemas {
+		names = append(names, name)
+	}
+	for name := range structs {
+		if _, ok := schemas[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		schemaPath := "components.schemas." + name
+		ref, value := schemas[name], structs[name]
+		switch {
+		case ref == nil:
+			c.differ(schemaPath, "the generated struct has no schema")
+		case ref.Value == nil || !isObject(ref.Value):
+			// only the objects are checked, the other schemas are generated as aliases
+		case value == nil:
+			c.differ(schemaPath, "the schema has no generated struct")
+		default:
+			c.compareStruct(schemaPath, ref.Value, reflect.TypeOf(value))
+		}
+	}
+	return c.differences
+}
+
+type structComparer struct {
+	differences []Difference
+}
+
+func (c *structComparer) differ(path, format string, args ...interface{}) {
+	c.differences = append(c.differences, Difference{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+func (c *structComparer) compareStruct(schemaPath string, schema *openapi3.Schema, typ reflect.Type) {
+	if typ.Kind() != reflect.Struct {
+		c.differ(schemaPath, "the generated %s is not a struct", typ)
+		return
+	}
+	fields := jsonFields(typ)
+	required := make(map[string]bool, len(schema.Required))
+	for _, name := range schema.Required {
+		required[name] = true
+	}
+
+	names := make([]string, 0, len(schema.Properties)+len(fields))
+	for name := range schema.Properties {
+		names = append(names, name)
+	}
+	for name := range fields {
+		if _, ok := schema.Properties[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		propertyPath := schemaPath + ".properties." + name
+		property := schema.Properties[name]
+		field, ok := fields[name]
+		switch {
+		case property == nil:
+			c.differ(propertyPath, "the generated field %s has no property", field.Name)
+		case !ok:
+			c.differ(propertyPath, "the property has no field in the generated %s", typ)
+		default:
+			c.compareField(propertyPath, property, required[name], field)
+		}
+	}
+}
+
+func (c *structComparer) compareField(propertyPath string, property *openapi3.SchemaRef, required bool, field reflect.StructField) {
+	typ := field.Type
+	wantPointer := !required || (property.Value != nil && property.Value.Nullable)
+	if isPointer := typ.Kind() == reflect.Pointer; isPointer != wantPointer {
+		if wantPointer {
+			c.differ(propertyPath, "the optional property is generated as the %s field %s instead of a pointer", typ, field.Name)
+		} else {
+			c.differ(propertyPath, "the required property is generated as the pointer field %s", field.Name)
+		}
+	}
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	c.compareType(propertyPath, property, typ)
+
+	// the siblings of a $ref are ignored, so its extra tags are the ones of the referenced schema
+	if property.Ref != "" || property.Value == nil {
+		return
+	}
+	extraTags, _ := property.Value.Extensions[tags.ExtraTagsExtension].(map[string]interface{})
+	keys := []string{"validate"}
+	for key := range extraTags {
+		if key != "validate" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys[1:])
+	for _, key := range keys {
+		want, _ := extraTags[key].(string)
+		if got := field.Tag.Get(key); got != want {
+			c.differ(propertyPath, "the %s tag of the generated field %s is %q instead of %q", key, field.Name, got, want)
+		}
+	}
+}
+
+// compareType compares the schema with the Go type generated for it, following the types
+// picked by oapi-codegen.
+func (c *structComparer) compareType(schemaPath string, ref *openapi3.SchemaRef, typ reflect.Type) {
+	if ref.Ref != "" {
+		if name := path.Base(ref.Ref); typ.Name() != name {
+			c.differ(schemaPath, "the %s schema is generated as %s", name, typ)
+		}
+		return
+	}
+	schema := ref.Value
+	if schema == nil {
+		return
+	}
+
+	var want string
+	switch {
+	case schema.Type.Is(openapi3.TypeString):
+		if name, ok := formatTypes[schema.Format]; ok {
+			if typ.Name() != name {
+				want = name
+			}
+		} else if typ.Kind() != reflect.String {
+			want = "string"
+		}
+	case schema.Type.Is(openapi3.TypeInteger):
+		if kind := numberKinds[schema.Format]; typ.Kind() != orKind(kind, reflect.Int) {
+			want = orKind(kind, reflect.Int).String()
+		}
+	case schema.Type.Is(openapi3.TypeNumber):
+		if kind := numberKinds[schema.Format]; typ.Kind() != orKind(kind, reflect.Float64) {
+			want = orKind(kind, reflect.Float64).String()
+		}
+	case schema.Type.Is(openapi3.TypeBoolean):
+		if typ.Kind() != reflect.Bool {
+			want = "bool"
+		}
+	case schema.Type.Is(openapi3.TypeArray):
+		if typ.Kind() != reflect.Slice {
+			want = "a slice"
+		} else if schema.Items != nil {
+			c.compareType(schemaPath+".items", schema.Items, typ.Elem())
+		}
+	case isObject(schema):
+		if len(schema.Properties) > 0 {
+			c.compareStruct(schemaPath, schema, typ)
+		} else if typ.Kind() != reflect.Map && typ.Kind() != reflect.Struct {
+			want = "a map"
+		}
+	}
+	if want != "" {
+		c.differ(schemaPath, "the schema is generated as %s instead of %s", typ, want)
+	}
+}
+
+// jsonFields returns the exported fields of the struct by their json name.
+func jsonFields(typ reflect.Type) map[string]reflect.StructField {
+	fields := make(map[string]reflect.StructField, typ.NumField())
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[name] = field
+	}
+	return fields
+}
+
+func isObject(schema *openapi3.Schema) bool {
+	return schema.Type.Is(openapi3.TypeObject) || (schema.Type == nil && len(schema.Properties) > 0)
+}
+
+func orKind(kind, def reflect.Kind) reflect.Kind {
+	if kind == reflect.Invalid {
+		return def
+	}
+	return kind
+}
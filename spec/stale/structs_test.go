@@ -0,0 +1,79 @@
+package stale
+
+import (
+	"testing"
+
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/stretchr/testify/require"
+)
+
+// Member is named after its schema, as the $ref fields are checked by the name of their type.
+type Member struct {
+	Email openapi_types.Email `json:"email"`
+}
+
+type createGuildReq struct {
+	Id     openapi_types.UUID `json:"id"`
+	Leader *Member            `json:"leader,omitempty"`
+	Name   string             `json:"name" validate:"required,min=3"`
+	Size   *int32             `json:"size,omitempty"`
+	Tags   *[]string          `json:"tags,omitempty"`
+}
+
+type staleGuildReq struct {
+	Id     string   `json:"id"`
+	Leader *Member  `json:"leader,omitempty"`
+	Name   *string  `json:"name" validate:"required"`
+	Size   *int     `json:"size,omitempty"`
+	Tags   *[]int   `json:"tags,omitempty"`
+	Region *string  `json:"region,omitempty"`
+	Extra  []string `json:"-"`
+}
+
+func TestCompareStructs(t *testing.T) {
+	tests := []struct {
+		name    string
+		structs Structs
+		want    []Difference
+	}{
+		{
+			name:    "given the structs generated from the spec, when we compare them, they should not differ",
+			structs: Structs{"CreateGuildReq": createGuildReq{}, "Member": Member{}},
+		},
+		{
+			name:    "given a schema without its struct, when we compare it, the struct should be missing",
+			structs: Structs{"CreateGuildReq": createGuildReq{}},
+			want:    []Difference{{Path: "components.schemas.Member", Message: "the schema has no generated struct"}},
+		},
+		{
+			name:    "given a struct without its schema, when we compare it, the schema should be missing",
+			structs: Structs{"CreateGuildReq": createGuildReq{}, "Member": Member{}, "Team": Member{}},
+			want:    []Difference{{Path: "components.schemas.Team", Message: "the generated struct has no schema"}},
+		},
+		{
+			name:    "given a struct generated from an older spec, when we compare it, every field should differ",
+			structs: Structs{"CreateGuildReq": staleGuildReq{}, "Member": Member{}},
+			want: []Difference{
+				{Path: "components.schemas.CreateGuildReq.properties.id", Message: "the schema is generated as string instead of UUID"},
+				{Path: "components.schemas.CreateGuildReq.properties.name", Message: "the required property is generated as the pointer field Name"},
+				{Path: "components.schemas.CreateGuildReq.properties.name", Message: `the validate tag of the generated field Name is "required" instead of "required,min=3"`},
+				{Path: "components.schemas.CreateGuildReq.properties.region", Message: "the generated field Region has no property"},
+				{Path: "components.schemas.CreateGuildReq.properties.size", Message: "the schema is generated as int instead of int32"},
+				{Path: "components.schemas.CreateGuildReq.properties.tags.items", Message: "the schema is generated as int instead of string"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			doc := loadSpec(t, guildsSpec)
+
+			// act
+			differences := CompareStructs(doc, tt.structs)
+
+			// assert
+			require.Equal(t, tt.want, differences)
+		})
+	}
+}
@@ -0,0 +1,270 @@
+// Package tags derives the go-playground validate tags of the generated Go structs from the
+// constraints of an OpenAPI document, so the spec of the Go validator doesn't have to repeat
+// every rule by hand.
+package tags
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+
+	"request_validator/validator/formats"
+)
+
+// codegenFormats are the formats that only pick the Go type of the generated fields.
+var codegenFormats = map[string]bool{
+	"int32":    true,
+	"int64":    true,
+	"float":    true,
+	"double":   true,
+	"binary":   true,
+	"password": true,
+}
+
+// FormatTag returns the validate tag of a string format, if the Go validator has one: the
+// formats of the formats library are registered as tags named after them.
+func FormatTag(format string) (string, bool) {
+	if _, ok := formats.Lookup(format); ok {
+		return format, true
+	}
+	return "", false
+}
+
+// Rule is a constraint of a schema expressed as a go-playground tag, such as "min=3".
+type Rule struct {
+	Tag   string
+	Param string
+}
+
+func (r Rule) String() string {
+	if r.Param == "" {
+		return r.Tag
+	}
+	return r.Tag + "=" + escapeParam(r.Param)
+}
+
+// Tag is the validate tag of a field: whether it is required, the rules of its value and,
+// for the arrays, the rules of their items after a dive.
+type Tag struct {
+	Required bool
+	// OmitEmpty skips the rules of the optional fields that are absent, which would otherwise
+	// fail on their zero value.
+	OmitEmpty bool
+	Rules     []Rule
+	// Dive is set for the arrays whose items are validated, even if they have no rules
+	// because they are structs.
+	Dive  bool
+	Items *Tag
+}
+
+// String returns the tag as it is written in the struct, such as
+// "required,min=1,dive,email".
+func (t *Tag) String() string {
+	var parts []string
+	if t.Required {
+		parts = append(parts, "required")
+	}
+	if t.OmitEmpty {
+		parts = append(parts, "omitempty")
+	}
+	for _, rule := range t.Rules {
+		parts = append(parts, rule.String())
+	}
+	if t.Dive {
+		parts = append(parts, "dive")
+		if t.Items != nil {
+			for _, rule := range t.Items.Rules {
+				parts = append(parts, rule.String())
+			}
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// Empty reports whether the tag has nothing to validate.
+func (t *Tag) Empty() bool {
+	return !t.Required && len(t.Rules) == 0 && !t.Dive
+}
+
+// Parse parses a validate tag written by String, or by hand in the same shape: an optional
+// required or omitempty, the rules of the value and, after a dive, the rules of the items.
+func Parse(tag string) (*Tag, error) {
+	t := &Tag{}
+	if tag == "" {
+		return t, nil
+	}
+
+	rules := &t.Rules
+	for _, part := range strings.Split(tag, ",") {
+		name, param, _ := strings.Cut(part, "=")
+		switch {
+		case name == "":
+			return nil, fmt.Errorf("the %q tag has an empty rule", tag)
+		case name == "dive":
+			if t.Dive {
+				return nil, fmt.Errorf("the %q tag dives into nested arrays", tag)
+			}
+			t.Dive, t.Items = true, &Tag{}
+			rules = &t.Items.Rules
+		case name == "required" && !t.Dive:
+			t.Required = true
+		case name == "omitempty" && !t.Dive:
+			t.OmitEmpty = true
+		default:
+			*rules = append(*rules, Rule{Tag: name, Param: unescapeParam(param)})
+		}
+	}
+	return t, nil
+}
+
+// FromSchema returns the validate tag of a property with the given schema, along with the
+// warnings about the constraints that can't be expressed as tags. A nil schema, such as the
+// one of an unresolved $ref, is treated as a struct. The optional fields with rules are
+// tagged with omitempty.
+func FromSchema(schema *openapi3.Schema, required bool) (*Tag, []string) {
+	tag, warnings := fromSchema(schema, required)
+	tag.OmitEmpty = !tag.Required && (len(tag.Rules) > 0 || tag.Dive)
+	return tag, warnings
+}
+
+func fromSchema(schema *openapi3.Schema, required bool) (*Tag, []string) {
+	tag := &Tag{}
+	var warnings []string
+	if schema == nil {
+		tag.Required = required
+		return tag, warnings
+	}
+
+	switch {
+	case schema.Type.Is(openapi3.TypeString):
+		tag.Required = required
+		warnings = append(warnings, stringRules(schema, tag)...)
+	case schema.Type.Is(openapi3.TypeInteger), schema.Type.Is(openapi3.TypeNumber):
+		// the zero value of the numbers fails the required tag even when it is present
+		if required {
+			warnings = append(warnings, "the required numbers can't be checked by the required tag")
+		}
+		warnings = append(warnings, numberRules(schema, tag)...)
+	case schema.Type.Is(openapi3.TypeBoolean):
+		if required {
+			warnings = append(warnings, "the required booleans can't be checked by the required tag")
+		}
+	case schema.Type.Is(openapi3.TypeArray):
+		tag.Required = required
+		warnings = append(warnings, arrayRules(schema, tag)...)
+	default:
+		tag.Required = required
+	}
+	return tag, warnings
+}
+
+func stringRules(schema *openapi3.Schema, tag *Tag) []string {
+	var warnings []string
+	if schema.Format != "" && !codegenFormats[schema.Format] {
+		if formatTag, ok := FormatTag(schema.Format); ok {
+			tag.Rules = append(tag.Rules, Rule{Tag: formatTag})
+		} else {
+			warnings = append(warnings, fmt.Sprintf("the %q format has no validate tag", schema.Format))
+		}
+	}
+	if schema.MinLength > 0 {
+		tag.Rules = append(tag.Rules, Rule{Tag: "min", Param: strconv.FormatUint(schema.MinLength, 10)})
+	}
+	if schema.MaxLength != nil {
+		tag.Rules = append(tag.Rules, Rule{Tag: "max", Param: strconv.FormatUint(*schema.MaxLength, 10)})
+	}
+	if schema.Pattern != "" {
+		tag.Rules = append(tag.Rules, Rule{Tag: "pattern", Param: schema.Pattern})
+	}
+	return append(warnings, enumRule(schema, tag)...)
+}
+
+func numberRules(schema *openapi3.Schema, tag *Tag) []string {
+	if schema.Min != nil {
+		rule := Rule{Tag: "gte", Param: formatFloat(*schema.Min)}
+		if schema.ExclusiveMin {
+			rule.Tag = "gt"
+		}
+		tag.Rules = append(tag.Rules, rule)
+	}
+	if schema.Max != nil {
+		rule := Rule{Tag: "lte", Param: formatFloat(*schema.Max)}
+		if schema.ExclusiveMax {
+			rule.Tag = "lt"
+		}
+		tag.Rules = append(tag.Rules, rule)
+	}
+
+	var warnings []string
+	if schema.MultipleOf != nil {
+		warnings = append(warnings, "the multipleOf constraint has no validate tag")
+	}
+	return append(warnings, enumRule(schema, tag)...)
+}
+
+func arrayRules(schema *openapi3.Schema, tag *Tag) []string {
+	if schema.MinItems > 0 {
+		tag.Rules = append(tag.Rules, Rule{Tag: "min", Param: strconv.FormatUint(schema.MinItems, 10)})
+	}
+	if schema.MaxItems != nil {
+		tag.Rules = append(tag.Rules, Rule{Tag: "max", Param: strconv.FormatUint(*schema.MaxItems, 10)})
+	}
+	if schema.UniqueItems {
+		tag.Rules = append(tag.Rules, Rule{Tag: "unique"})
+	}
+	if schema.Items == nil {
+		return nil
+	}
+
+	items := schema.Items.Value
+	if items == nil || items.Type.Is(openapi3.TypeObject) || items.Type.Is(openapi3.TypeArray) {
+		// the structs are only validated with a dive, the nested arrays need a dive of their own
+		tag.Dive = true
+		if items != nil && items.Type.Is(openapi3.TypeArray) {
+			return []string{"the items of the nested arrays are not validated"}
+		}
+		return nil
+	}
+
+	itemTag, warnings := FromSchema(items, false)
+	if len(itemTag.Rules) > 0 {
+		tag.Dive, tag.Items = true, itemTag
+	}
+	return warnings
+}
+
+// enumRule adds the oneof rule of the enum, whose values are separated by spaces, so the
+// values with spaces can't be expressed.
+func enumRule(schema *openapi3.Schema, tag *Tag) []string {
+	if len(schema.Enum) == 0 {
+		return nil
+	}
+	values := make([]string, 0, len(schema.Enum))
+	for _, value := range schema.Enum {
+		s := fmt.Sprint(value)
+		if f, ok := value.(float64); ok {
+			s = formatFloat(f)
+		}
+		if s == "" || strings.ContainsAny(s, " \t\n") {
+			return []string{fmt.Sprintf("the enum value %q can't be a oneof value", s)}
+		}
+		values = append(values, s)
+	}
+	tag.Rules = append(tag.Rules, Rule{Tag: "oneof", Param: strings.Join(values, " ")})
+	return nil
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// escapeParam escapes the separators of the tags in a param, which go-playground unescapes.
+func escapeParam(param string) string {
+	return strings.NewReplacer(",", "0x2C", "|", "0x7C").Replace(param)
+}
+
+func unescapeParam(param string) string {
+	return strings.NewReplacer("0x2C", ",", "0x7C", "|").Replace(param)
+}
@@ -0,0 +1,137 @@
+package tags
+
+import (
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromSchema(t *testing.T) {
+	tests := []struct {
+		name         string
+		schema       *openapi3.Schema
+		required     bool
+		wantTag      string
+		wantWarnings int
+	}{
+		{
+			name:     "given a required string with a format, when we derive its tag, the format tag should follow required",
+			schema:   openapi3.NewUUIDSchema(),
+			required: true,
+			wantTag:  "required,uuid",
+		},
+		{
+			name:    "given an optional string with a format of the formats library, when we derive its tag, it should start with omitempty",
+			schema:  openapi3.NewStringSchema().WithFormat("date-time"),
+			wantTag: "omitempty,date-time",
+		},
+		{
+			name:    "given an optional string without constraints, when we derive its tag, it should be empty",
+			schema:  openapi3.NewStringSchema(),
+			wantTag: "",
+		},
+		{
+			name:     "given a string with length bounds, a pattern and an enum, when we derive its tag, every constraint should be a rule",
+			schema:   openapi3.NewStringSchema().WithMinLength(2).WithMaxLength(5).WithPattern("^[a-z,|]+$").WithEnum("ab", "abc"),
+			required: true,
+			wantTag:  "required,min=2,max=5,pattern=^[a-z0x2C0x7C]+$,oneof=ab abc",
+		},
+		{
+			name:    "given a number with an inclusive and an exclusive bound, when we derive its tag, the bounds should be gte and lt",
+			schema:  openapi3.NewFloat64Schema().WithMin(0.5).WithMax(10).WithExclusiveMax(true),
+			wantTag: "omitempty,gte=0.5,lt=10",
+		},
+		{
+			name:         "given a required integer, when we derive its tag, required should be skipped with a warning",
+			schema:       openapi3.NewIntegerSchema().WithMin(1),
+			required:     true,
+			wantTag:      "omitempty,gte=1",
+			wantWarnings: 1,
+		},
+		{
+			name:    "given an array with item constraints, when we derive its tag, the item rules should follow a dive",
+			schema:  openapi3.NewArraySchema().WithMinItems(1).WithMaxItems(3).WithUniqueItems(true).WithItems(openapi3.NewStringSchema().WithFormat("email")),
+			wantTag: "omitempty,min=1,max=3,unique,dive,email",
+		},
+		{
+			name:     "given a required array of objects, when we derive its tag, the structs should be validated with a dive",
+			schema:   openapi3.NewArraySchema().WithItems(openapi3.NewObjectSchema()),
+			required: true,
+			wantTag:  "required,dive",
+		},
+		{
+			name:         "given a string with an unknown format and an enum value with spaces, when we derive its tag, both should be warned",
+			schema:       openapi3.NewStringSchema().WithFormat("hex-color").WithEnum("dark red"),
+			wantTag:      "",
+			wantWarnings: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// act
+			tag, warnings := FromSchema(tt.schema, tt.required)
+
+			// assert
+			require.Equal(t, tt.wantTag, tag.String())
+			require.Len(t, warnings, tt.wantWarnings)
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		tag     string
+		want    *Tag
+		wantErr bool
+	}{
+		{
+			name: "given an empty tag, when we parse it, it should have nothing to validate",
+			tag:  "",
+			want: &Tag{},
+		},
+		{
+			name: "given a required tag with escaped params, when we parse it, the params should be unescaped",
+			tag:  "required,min=2,pattern=^[a-z0x2C0x7C]+$",
+			want: &Tag{Required: true, Rules: []Rule{{Tag: "min", Param: "2"}, {Tag: "pattern", Param: "^[a-z,|]+$"}}},
+		},
+		{
+			name: "given a tag with a dive, when we parse it, the rules after the dive should be the items ones",
+			tag:  "omitempty,unique,dive,email",
+			want: &Tag{OmitEmpty: true, Rules: []Rule{{Tag: "unique"}}, Dive: true, Items: &Tag{Rules: []Rule{{Tag: "email"}}}},
+		},
+		{
+			name: "given an optional tag without omitempty, when we parse it, omitempty should not be set",
+			tag:  "email",
+			want: &Tag{Rules: []Rule{{Tag: "email"}}},
+		},
+		{
+			name:    "given a tag with an empty rule, when we parse it, it should error",
+			tag:     "required,,email",
+			wantErr: true,
+		},
+		{
+			name:    "given a tag with two dives, when we parse it, it should error",
+			tag:     "dive,dive",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// act
+			tag, err := Parse(tt.tag)
+
+			// assert
+			if tt.wantErr {
+				require.Error(t, err, "parse should error")
+				return
+			}
+			require.NoError(t, err, "parse should not error")
+			require.Equal(t, tt.want, tag)
+			require.Equal(t, tt.tag, tag.String(), "the tag should be written back as it was")
+		})
+	}
+}
@@ -0,0 +1,252 @@
+package tags
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"gopkg.in/yaml.v3"
+)
+
+// ExtraTagsExtension is the extension of oapi-codegen with the extra tags of a field.
+const ExtraTagsExtension = "x-oapi-codegen-extra-tags"
+
+// Warning is a constraint of the document that can't be expressed as a validate tag.
+type Warning struct {
+	// Path is the path of the property in the document, such as
+	// "components.schemas.CreateUserReq.properties.id".
+	Path    string
+	Message string
+}
+
+func (w Warning) String() string {
+	return fmt.Sprintf("%s: %s", w.Path, w.Message)
+}
+
+// Option configures the behaviour of Transform.
+type Option func(*options)
+
+type options struct {
+	keepFormats bool
+	header      string
+}
+
+// WithKeepFormats keeps the string formats that are turned into validate tags. By default
+// they are removed because oapi-codegen would generate fields of types, such as
+// openapi_types.UUID or time.Time, that the string tags can't validate.
+func WithKeepFormats() Option {
+	return func(o *options) {
+		o.keepFormats = true
+	}
+}
+
+// WithHeader sets the comment written at the top of the transformed document, such as a
+// notice that it is generated.
+func WithHeader(header string) Option {
+	return func(o *options) {
+		o.header = header
+	}
+}
+
+// Transform reads an OpenAPI document and returns the variant whose properties have the
+// validate tags of their constraints in the oapi-codegen extra tags, keeping the rest of the
+// document, its order and its comments. The validate tags already in the document are
+// replaced.
+func Transform(src []byte, opts ...Option) ([]byte, []Warning, error) {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(src, &doc); err != nil {
+		return nil, nil, fmt.Errorf("unable to parse the document: %w", err)
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, nil, fmt.Errorf("the document is not a YAML or JSON object")
+	}
+
+	t := &transformer{options: o}
+	if err := t.walk(doc.Content[0], nil); err != nil {
+		return nil, nil, err
+	}
+	if o.header != "" {
+		doc.Content[0].HeadComment = o.header
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return nil, nil, fmt.Errorf("unable to encode the document: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, nil, fmt.Errorf("unable to encode the document: %w", err)
+	}
+	return buf.Bytes(), t.warnings, nil
+}
+
+type transformer struct {
+	options  *options
+	warnings []Warning
+}
+
+// opaqueKeys hold values that are not part of the schemas, so their objects are not tagged.
+var opaqueKeys = map[string]bool{
+	"example":  true,
+	"examples": true,
+	"default":  true,
+	"enum":     true,
+}
+
+// walk visits every node of the document, tagging the properties of the object schemas.
+func (t *transformer) walk(node *yaml.Node, path []string) error {
+	switch node.Kind {
+	case yaml.MappingNode:
+		if properties := mappingValue(node, "properties"); properties != nil && properties.Kind == yaml.MappingNode {
+			if err := t.tagProperties(node, properties, path); err != nil {
+				return err
+			}
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i].Value
+			if opaqueKeys[key] || strings.HasPrefix(key, "x-") {
+				continue
+			}
+			if err := t.walk(node.Content[i+1], append(path, key)); err != nil {
+				return err
+			}
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			if err := t.walk(item, append(path, fmt.Sprint(i))); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (t *transformer) tagProperties(object, properties *yaml.Node, path []string) error {
+	required := make(map[string]bool)
+	if list := mappingValue(object, "required"); list != nil && list.Kind == yaml.SequenceNode {
+		for _, name := range list.Content {
+			required[name.Value] = true
+		}
+	}
+
+	for i := 0; i+1 < len(properties.Content); i += 2 {
+		name, property := properties.Content[i].Value, properties.Content[i+1]
+		propertyPath := strings.Join(append(append([]string(nil), path...), "properties", name), ".")
+		if property.Kind != yaml.MappingNode {
+			continue
+		}
+		if mappingValue(property, "$ref") != nil {
+			// the siblings of a $ref are ignored, a struct only needs a tag to be required
+			if required[name] {
+				t.warn(propertyPath, "the required $ref properties can't have a validate tag")
+			}
+			continue
+		}
+
+		schema, err := decodeSchema(property)
+		if err != nil {
+			return fmt.Errorf("unable to decode the schema of %s: %w", propertyPath, err)
+		}
+		tag, warnings := FromSchema(schema, required[name])
+		for _, warning := range warnings {
+			t.warn(propertyPath, warning)
+		}
+		setValidateTag(property, tag)
+		if !t.options.keepFormats {
+			removeTaggedFormats(property)
+		}
+	}
+	return nil
+}
+
+func (t *transformer) warn(path, msg string) {
+	t.warnings = append(t.warnings, Warning{Path: path, Message: msg})
+}
+
+// decodeSchema decodes the node of a schema, leaving its $refs unresolved.
+func decodeSchema(node *yaml.Node) (*openapi3.Schema, error) {
+	var value interface{}
+	if err := node.Decode(&value); err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	schema := &openapi3.Schema{}
+	if err := schema.UnmarshalJSON(data); err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
+
+// setValidateTag sets the validate tag of the property in its extra tags, keeping the other
+// extra tags, or removes it if the tag is empty.
+func setValidateTag(property *yaml.Node, tag *Tag) {
+	extraTags := mappingValue(property, ExtraTagsExtension)
+	if extraTags == nil || extraTags.Kind != yaml.MappingNode {
+		if tag.Empty() {
+			return
+		}
+		extraTags = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		setMappingValue(property, ExtraTagsExtension, extraTags)
+	}
+
+	if tag.Empty() {
+		deleteMappingValue(extraTags, "validate")
+		if len(extraTags.Content) == 0 {
+			deleteMappingValue(property, ExtraTagsExtension)
+		}
+		return
+	}
+	setMappingValue(extraTags, "validate", &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: tag.String()})
+}
+
+// removeTaggedFormats removes the string formats turned into tags from the property and the
+// items of its arrays.
+func removeTaggedFormats(property *yaml.Node) {
+	for node := property; node != nil && node.Kind == yaml.MappingNode; node = mappingValue(node, "items") {
+		typ, format := mappingValue(node, "type"), mappingValue(node, "format")
+		if typ != nil && typ.Value == openapi3.TypeString && format != nil {
+			if _, ok := FormatTag(format.Value); ok {
+				deleteMappingValue(node, "format")
+			}
+		}
+	}
+}
+
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func setMappingValue(node *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			node.Content[i+1] = value
+			return
+		}
+	}
+	node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+}
+
+func deleteMappingValue(node *yaml.Node, key string) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			node.Content = append(node.Content[:i], node.Content[i+2:]...)
+			return
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package tags
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const plainSpec = `openapi: 3.0.0
+info:
+  title: Guilds
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    CreateGuildReq:
+      type: object
+      required: [name, leader, members]
+      properties:
+        name:
+          type: string
+          minLength: 3
+          example:
+            properties:
+              untouched: true
+        # the leader is described by its own schema
+        leader:
+          $ref: '#/components/schemas/Member'
+        members:
+          type: array
+          items:
+            type: string
+            format: email
+        size:
+          type: integer
+          format: int32
+          maximum: 100
+          x-oapi-codegen-extra-tags:
+            validate: stale
+            db: size
+    Member:
+      type: object
+      properties:
+        email:
+          type: string
+          format: email
+`
+
+const taggedSpec = `# generated
+openapi: 3.0.0
+info:
+  title: Guilds
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    CreateGuildReq:
+      type: object
+      required: [name, leader, members]
+      properties:
+        name:
+          type: string
+          minLength: 3
+          example:
+            properties:
+              untouched: true
+          x-oapi-codegen-extra-tags:
+            validate: required,min=3
+        # the leader is described by its own schema
+        leader:
+          $ref: '#/components/schemas/Member'
+        members:
+          type: array
+          items:
+            type: string
+          x-oapi-codegen-extra-tags:
+            validate: required,dive,email
+        size:
+          type: integer
+          format: int32
+          maximum: 100
+          x-oapi-codegen-extra-tags:
+            validate: omitempty,lte=100
+            db: size
+    Member:
+      type: object
+      properties:
+        email:
+          type: string
+          x-oapi-codegen-extra-tags:
+            validate: omitempty,email
+`
+
+func TestTransform(t *testing.T) {
+	// act
+	tagged, warnings, err := Transform([]byte(plainSpec), WithHeader("generated"))
+
+	// assert
+	require.NoError(t, err, "transform should not error")
+	require.Equal(t, taggedSpec, string(tagged))
+	require.Equal(t, []Warning{{
+		Path:    "components.schemas.CreateGuildReq.properties.leader",
+		Message: "the required $ref properties can't have a validate tag",
+	}}, warnings)
+}
+
+func TestTransformKeepFormats(t *testing.T) {
+	// act
+	tagged, _, err := Transform([]byte(plainSpec), WithKeepFormats())
+
+	// assert
+	require.NoError(t, err, "transform should not error")
+	require.Contains(t, string(tagged), "format: email")
+}
+
+func TestTransformInvalidDocument(t *testing.T) {
+	// act
+	_, _, err := Transform([]byte("- not\n- an object\n"))
+
+	// assert
+	require.Error(t, err, "transform should error")
+}
+
+func TestV2SpecIsDerivedFromV1(t *testing.T) {
+	// arrange
+	v1, err := os.ReadFile("../../http/v1/api.yaml")
+	require.NoError(t, err)
+	v2, err := os.ReadFile("../../http/v2/api.yaml")
+	require.NoError(t, err)
+
+	// act
+	tagged, _, err := Transform(v1, WithHeader("Code generated by spectags from ../v1/api.yaml. DO NOT EDIT."))
+
+	// assert
+	require.NoError(t, err, "transform should not error")
+	require.Equal(t, string(tagged), string(v2), "http/v2/api.yaml is stale, run go generate ./http/v2")
+}
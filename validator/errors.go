@@ -0,0 +1,410 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	ut "github.com/go-playground/universal-translator"
+	playground "github.com/go-playground/validator"
+
+	"request_validator/validator/formats"
+	govalidator "request_validator/validator/go_validator"
+	"request_validator/validator/i18n"
+	kinvalidator "request_validator/validator/kin_validator"
+)
+
+// unexpectedContentTypeReason is the reason used by openapi3filter when the request
+// content type is not declared in the specification.
+const unexpectedContentTypeReason = "header Content-Type has unexpected value"
+
+// Location identifies the part of the http request where a violation was found.
+type Location string
+
+const (
+	LocationBody   Location = "body"
+	LocationPath   Location = "path"
+	LocationQuery  Location = "query"
+	LocationHeader Location = "header"
+	LocationCookie Location = "cookie"
+	LocationServer Location = "server"
+)
+
+// Violation describes a single rule of the specification that the request does not follow.
+type Violation struct {
+	// Pointer is the JSON pointer (RFC 6901) to the offending field inside its location.
+	Pointer string `json:"pointer"`
+	// Rule is the name of the failed rule, using the OpenAPI vocabulary (required, format, enum...).
+	Rule string `json:"rule"`
+	// Value is the offending value, if there is one.
+	Value interface{} `json:"value,omitempty"`
+	// Location is the part of the request where the violation was found.
+	Location Location `json:"location"`
+	// Message is a human readable description of the violation.
+	Message string `json:"message"`
+}
+
+// ValidationError is the implementation agnostic representation of a failed request validation.
+type ValidationError struct {
+	Violations []Violation
+	// Err is the original error returned by the validator implementation.
+	Err error
+}
+
+func (e *ValidationError) Error() string {
+	if len(e.Violations) == 0 && e.Err != nil {
+		return "request validation failed: " + e.Err.Error()
+	}
+	msgs := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		if v.Pointer == "" {
+			msgs = append(msgs, fmt.Sprintf("%s: %s", v.Location, v.Message))
+			continue
+		}
+		msgs = append(msgs, fmt.Sprintf("%s %s: %s", v.Location, v.Pointer, v.Message))
+	}
+	return "request validation failed: " + strings.Join(msgs, " | ")
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// FromError converts the error returned by any of the validator implementations
+// into a ValidationError. It returns nil if err is nil.
+func FromError(err error) *ValidationError {
+	return FromErrorLocalized(err, nil)
+}
+
+// FromErrorLocalized converts the error like FromError, with the messages of the schema and
+// field violations in the language of the translator, such as the one picked for the request
+// by i18n.Translator.FromRequest, or in English if trans is nil. It returns nil if err is nil.
+func FromErrorLocalized(err error, trans ut.Translator) *ValidationError {
+	if err == nil {
+		return nil
+	}
+
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		return validationErr
+	}
+
+	if isProgrammingError(err) {
+		return &ValidationError{Err: err}
+	}
+	if isGoError(err) {
+		return fromGoError(err, trans)
+	}
+	return &ValidationError{Violations: kinViolations(err, trans), Err: err}
+}
+
+// isProgrammingError reports whether err is a mistake of the caller rather than of the
+// request, such as a target the body can't be decoded into, so it has no violations.
+func isProgrammingError(err error) bool {
+	var targetErr *govalidator.InvalidTargetError
+	return errors.As(err, &targetErr)
+}
+
+// isGoError reports whether err was returned by the go-playground validator implementation.
+func isGoError(err error) bool {
+	var fieldErrs playground.ValidationErrors
+	var mismatchErr *govalidator.TypeMismatchError
+	var unknownErr *govalidator.UnknownFieldError
+	var duplicateErr *govalidator.DuplicateKeyError
+	var trailingErr *govalidator.TrailingDataError
+	var paramErrs govalidator.ParamErrors
+	return errors.As(err, &fieldErrs) ||
+		errors.As(err, &paramErrs) ||
+		errors.As(err, &mismatchErr) ||
+		errors.As(err, &unknownErr) ||
+		errors.As(err, &duplicateErr) ||
+		errors.As(err, &trailingErr) ||
+		errors.Is(err, govalidator.ErrEmptyBody) ||
+		errors.Is(err, govalidator.ErrBodyTooLarge)
+}
+
+// FromKinError converts an error returned by the kin-openapi validator into a ValidationError.
+// It returns nil if err is nil.
+func FromKinError(err error) *ValidationError {
+	if err == nil {
+		return nil
+	}
+	return &ValidationError{Violations: kinViolations(err, nil), Err: err}
+}
+
+// FromGoError converts an error returned by the go-playground validator into a ValidationError.
+// It returns nil if err is nil.
+func FromGoError(err error) *ValidationError {
+	if err == nil {
+		return nil
+	}
+	if isProgrammingError(err) {
+		return &ValidationError{Err: err}
+	}
+	return fromGoError(err, nil)
+}
+
+// fromGoError converts the error of the go-playground validator, translating the messages of
+// the field violations if trans is not nil.
+func fromGoError(err error, trans ut.Translator) *ValidationError {
+
+	var paramErrs govalidator.ParamErrors
+	var mismatchErr *govalidator.TypeMismatchError
+	var unknownErr *govalidator.UnknownFieldError
+	var duplicateErr *govalidator.DuplicateKeyError
+	switch {
+	case errors.As(err, &paramErrs):
+		return &ValidationError{Violations: goParamViolations(paramErrs, trans), Err: err}
+	case errors.As(err, &mismatchErr):
+		return goDecodeError(err, dottedPathToPointer(mismatchErr.Field), "type")
+	case errors.As(err, &unknownErr):
+		// the decoder only reports the name of the unknown field, not its path
+		return goDecodeError(err, "", "additionalProperties")
+	case errors.As(err, &duplicateErr):
+		return goDecodeError(err, dottedPathToPointer(duplicateErr.Field), "duplicateKey")
+	}
+
+	var fieldErrs playground.ValidationErrors
+	if !errors.As(err, &fieldErrs) {
+		return &ValidationError{
+			Violations: []Violation{{Rule: "parse", Location: LocationBody, Message: err.Error()}},
+			Err:        err,
+		}
+	}
+
+	violations := make([]Violation, 0, len(fieldErrs))
+	for _, fe := range fieldErrs {
+		msg := fmt.Sprintf("field doesn't match the %q rule", fe.Tag())
+		if trans != nil {
+			msg = i18n.TranslateFieldError(trans, fe)
+		}
+		violations = append(violations, Violation{
+			Pointer:  govalidator.JSONPointer(fe),
+			Rule:     goRule(fe),
+			Value:    goValue(fe),
+			Location: LocationBody,
+			Message:  msg,
+		})
+	}
+	return &ValidationError{Violations: violations, Err: err}
+}
+
+// goDecodeError creates the ValidationError of a request body that couldn't be decoded.
+func goDecodeError(err error, pointer, rule string) *ValidationError {
+	return &ValidationError{
+		Violations: []Violation{{Pointer: pointer, Rule: rule, Location: LocationBody, Message: err.Error()}},
+		Err:        err,
+	}
+}
+
+// goParamViolations converts the param errors, reporting the values that can't be converted
+// with the "parse" rule like the kin-openapi validator does.
+func goParamViolations(paramErrs govalidator.ParamErrors, trans ut.Translator) []Violation {
+	violations := make([]Violation, 0, len(paramErrs))
+	for _, paramErr := range paramErrs {
+		v := Violation{
+			Pointer:  "/" + escapePointerToken(paramErr.Name),
+			Rule:     "parse",
+			Value:    paramErr.Value,
+			Location: Location(paramErr.In),
+			Message:  paramErr.Error(),
+		}
+		if fe := paramErr.FieldError; fe != nil {
+			v.Rule, v.Value = goRule(fe), goValue(fe)
+			if trans != nil {
+				v.Message = i18n.FieldErrorMessage(trans, fe, paramErr.Name)
+			}
+		}
+		violations = append(violations, v)
+	}
+	return violations
+}
+
+func kinViolations(err error, trans ut.Translator) []Violation {
+	if multiErr, ok := requestMultiError(err); ok {
+		var violations []Violation
+		for _, e := range multiErr {
+			violations = append(violations, kinViolations(e, trans)...)
+		}
+		return violations
+	}
+
+	switch {
+	case errors.Is(err, routers.ErrPathNotFound):
+		return []Violation{{Rule: "server", Location: LocationServer, Message: routers.ErrPathNotFound.Error()}}
+	case errors.Is(err, routers.ErrMethodNotAllowed):
+		return []Violation{{Rule: "method", Location: LocationServer, Message: routers.ErrMethodNotAllowed.Error()}}
+	}
+
+	var securityErr *openapi3filter.SecurityRequirementsError
+	if errors.As(err, &securityErr) {
+		return []Violation{{Rule: "security", Location: LocationHeader, Message: securityErr.Error()}}
+	}
+
+	var requestErr *openapi3filter.RequestError
+	if !errors.As(err, &requestErr) {
+		return []Violation{{Rule: "parse", Location: LocationBody, Message: err.Error()}}
+	}
+
+	location, pointer := LocationBody, ""
+	if p := requestErr.Parameter; p != nil {
+		location, pointer = Location(p.In), "/"+escapePointerToken(p.Name)
+	}
+
+	if requestErr.Err == nil {
+		rule := "invalid"
+		if strings.HasPrefix(requestErr.Reason, unexpectedContentTypeReason) {
+			rule = "content-type"
+		}
+		return []Violation{{Pointer: pointer, Rule: rule, Location: location, Message: requestErr.Error()}}
+	}
+	return causeViolations(requestErr.Err, location, pointer, trans)
+}
+
+// requestMultiError returns the multi error that collects several request errors, ignoring
+// the multi errors that are wrapped by a single request error.
+func requestMultiError(err error) (openapi3.MultiError, bool) {
+	for ; err != nil; err = errors.Unwrap(err) {
+		switch e := err.(type) {
+		case openapi3.MultiError:
+			return e, true
+		case *openapi3filter.RequestError:
+			return nil, false
+		}
+	}
+	return nil, false
+}
+
+func causeViolations(err error, location Location, prefix string, trans ut.Translator) []Violation {
+	var multiErr openapi3.MultiError
+	if errors.As(err, &multiErr) {
+		var violations []Violation
+		for _, e := range multiErr {
+			violations = append(violations, causeViolations(e, location, prefix, trans)...)
+		}
+		return violations
+	}
+
+	var schemaErr *openapi3.SchemaError
+	if errors.As(err, &schemaErr) {
+		pointer := schemaErr.JSONPointer()
+		var formatErr *kinvalidator.FormatError
+		if errors.As(err, &formatErr) {
+			pointer = formatErr.JSONPointer()
+		}
+
+		v := Violation{
+			Pointer:  prefix + pathToPointer(pointer),
+			Rule:     schemaErr.SchemaField,
+			Value:    schemaErr.Value,
+			Location: location,
+			Message:  schemaErr.Reason,
+		}
+		if v.Rule == "required" {
+			// the value of a missing property is the object that should contain it
+			v.Value = nil
+		}
+		if v.Message == "" {
+			v.Message = fmt.Sprintf("doesn't match schema %q", schemaErr.SchemaField)
+		}
+		if trans != nil {
+			v.Message = kinvalidator.TranslateSchemaError(trans, schemaErr, lastPointerToken(v.Pointer))
+		}
+		return []Violation{v}
+	}
+
+	var parseErr *openapi3filter.ParseError
+	if errors.As(err, &parseErr) {
+		path := make([]string, 0, len(parseErr.Path()))
+		for _, p := range parseErr.Path() {
+			path = append(path, fmt.Sprint(p))
+		}
+		return []Violation{{
+			Pointer:  prefix + pathToPointer(path),
+			Rule:     "parse",
+			Value:    parseErr.Value,
+			Location: location,
+			Message:  parseErr.Error(),
+		}}
+	}
+
+	rule, msg := "invalid", err.Error()
+	if errors.Is(err, openapi3filter.ErrInvalidRequired) {
+		rule = "required"
+		if trans != nil {
+			msg = i18n.Message(trans, i18n.RuleRequired, lastPointerToken(prefix), "")
+		}
+	}
+	return []Violation{{Pointer: prefix, Rule: rule, Location: location, Message: msg}}
+}
+
+// goRules translates the go-playground tags into the OpenAPI vocabulary so both
+// implementations report the same rule names.
+var goRules = map[string]string{
+	"required":     "required",
+	"oneof":        "enum",
+	"uuid":         "format",
+	"uuid3":        "format",
+	"uuid4":        "format",
+	"uuid5":        "format",
+	"uuid_rfc4122": "format",
+	"email":        "format",
+	"uri":          "format",
+	"url":          "format",
+	"hostname":     "format",
+	"ipv4":         "format",
+	"ipv6":         "format",
+	"base64":       "format",
+	"e164":         "format",
+	"unique":       "uniqueItems",
+	"pattern":      "pattern",
+}
+
+func goRule(fe playground.FieldError) string {
+	if rule, ok := goRules[fe.Tag()]; ok {
+		return rule
+	}
+	if _, ok := formats.Lookup(fe.Tag()); ok {
+		return "format"
+	}
+	return fe.Tag()
+}
+
+func goValue(fe playground.FieldError) interface{} {
+	if fe.Tag() == "required" {
+		return nil
+	}
+	return fe.Value()
+}
+
+// dottedPathToPointer converts a dotted path of JSON fields such as "items.3.email" into a
+// JSON pointer such as "/items/3/email".
+func dottedPathToPointer(path string) string {
+	if path == "" {
+		return ""
+	}
+	return pathToPointer(strings.Split(path, "."))
+}
+
+// lastPointerToken returns the unescaped last token of a JSON pointer, empty for the pointer
+// of the whole value.
+func lastPointerToken(pointer string) string {
+	token := pointer[strings.LastIndexByte(pointer, '/')+1:]
+	return strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+}
+
+func pathToPointer(path []string) string {
+	var sb strings.Builder
+	for _, token := range path {
+		sb.WriteByte('/')
+		sb.WriteString(escapePointerToken(token))
+	}
+	return sb.String()
+}
+
+func escapePointerToken(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
@@ -0,0 +1,231 @@
+package validator
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	govalidator "request_validator/validator/go_validator"
+)
+
+func TestFromError(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		impl     Implementation
+		req      string
+		url      string
+		wantFunc func(t *testing.T, err *ValidationError)
+	}{
+		{
+			name: "given the kin validator and a request whose ID is not of a UUID type, when we convert the error, a format violation should be returned",
+			impl: Kin,
+			req:  invalidFormatFieldRequest,
+			url:  validURL,
+			wantFunc: func(t *testing.T, err *ValidationError) {
+				require.Len(t, err.Violations, 1)
+				require.Equal(t, "/id", err.Violations[0].Pointer)
+				require.Equal(t, "format", err.Violations[0].Rule)
+				require.Equal(t, "sadwefsds", err.Violations[0].Value)
+				require.Equal(t, LocationBody, err.Violations[0].Location)
+			},
+		},
+		{
+			name: "given the kin validator and a request that does not have a required field specified, when we convert the error, a required violation should be returned",
+			impl: Kin,
+			req:  missingMandatoryFieldRequest,
+			url:  validURL,
+			wantFunc: func(t *testing.T, err *ValidationError) {
+				require.Len(t, err.Violations, 1)
+				require.Equal(t, "/id", err.Violations[0].Pointer)
+				require.Equal(t, "required", err.Violations[0].Rule)
+				require.Nil(t, err.Violations[0].Value)
+				require.Equal(t, LocationBody, err.Violations[0].Location)
+			},
+		},
+		{
+			name: "given the kin validator and a request that does not come from one of the specified url server, when we convert the error, a server violation should be returned",
+			impl: Kin,
+			req:  correctRequest,
+			url:  "XXXXXXXXXXX",
+			wantFunc: func(t *testing.T, err *ValidationError) {
+				require.Len(t, err.Violations, 1)
+				require.Equal(t, "server", err.Violations[0].Rule)
+				require.Equal(t, LocationServer, err.Violations[0].Location)
+			},
+		},
+		{
+			name: "given the go validator and a request whose ID is not of a UUID type, when we convert the error, a format violation should be returned",
+			impl: Go,
+			req:  invalidFormatFieldRequest,
+			url:  validURL,
+			wantFunc: func(t *testing.T, err *ValidationError) {
+				require.Len(t, err.Violations, 1)
+				require.Equal(t, "/id", err.Violations[0].Pointer)
+				require.Equal(t, "format", err.Violations[0].Rule)
+				require.Equal(t, "sadwefsds", err.Violations[0].Value)
+				require.Equal(t, LocationBody, err.Violations[0].Location)
+			},
+		},
+		{
+			name: "given the go validator and a request that does not have a required field specified, when we convert the error, a required violation should be returned",
+			impl: Go,
+			req:  missingMandatoryFieldRequest,
+			url:  validURL,
+			wantFunc: func(t *testing.T, err *ValidationError) {
+				require.Len(t, err.Violations, 1)
+				require.Equal(t, "/id", err.Violations[0].Pointer)
+				require.Equal(t, "required", err.Violations[0].Rule)
+				require.Nil(t, err.Violations[0].Value)
+				require.Equal(t, LocationBody, err.Violations[0].Location)
+			},
+		},
+		{
+			name: "given the go validator and a malformed request, when we convert the error, a parse violation should be returned",
+			impl: Go,
+			req:  `{"id": `,
+			url:  validURL,
+			wantFunc: func(t *testing.T, err *ValidationError) {
+				require.Len(t, err.Violations, 1)
+				require.Equal(t, "parse", err.Violations[0].Rule)
+				require.Equal(t, LocationBody, err.Violations[0].Location)
+			},
+		},
+		{
+			name: "given the go validator and a request with a field of the wrong type, when we convert the error, a type violation should be returned",
+			impl: Go,
+			req:  `{"id": 42, "firstName": "Jon", "lastName": "Snow"}`,
+			url:  validURL,
+			wantFunc: func(t *testing.T, err *ValidationError) {
+				require.Len(t, err.Violations, 1)
+				require.Equal(t, "/id", err.Violations[0].Pointer)
+				require.Equal(t, "type", err.Violations[0].Rule)
+				require.Equal(t, LocationBody, err.Violations[0].Location)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			reqValidator := mustCreate(t, tt.impl)
+			httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, tt.url, bytes.NewReader([]byte(tt.req)))
+			require.NoError(t, err, "http request creation should not error")
+			httpRequest.Header.Add("Content-Type", "application/json")
+			validationErr := reqValidator.ValidateRequest(ctx, httpRequest)
+			require.Error(t, validationErr, "validator should error")
+
+			// act
+			converted := FromError(validationErr)
+
+			// assert
+			require.NotNil(t, converted)
+			require.Equal(t, validationErr, errors.Unwrap(converted), "converted error should wrap the original one")
+			tt.wantFunc(t, converted)
+		})
+	}
+
+	require.Nil(t, FromError(nil), "a nil error should not be converted")
+}
+
+func TestFromGoDecodeError(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		wantPointer string
+		wantRule    string
+	}{
+		{
+			name:        "given an unknown field error, when we convert it, an additional properties violation should be returned",
+			err:         &govalidator.UnknownFieldError{Field: "nickname"},
+			wantPointer: "",
+			wantRule:    "additionalProperties",
+		},
+		{
+			name:        "given a duplicate key error, when we convert it, a duplicate key violation with its pointer should be returned",
+			err:         &govalidator.DuplicateKeyError{Field: "items.3.email"},
+			wantPointer: "/items/3/email",
+			wantRule:    "duplicateKey",
+		},
+		{
+			name:        "given a trailing data error, when we convert it, a parse violation should be returned",
+			err:         &govalidator.TrailingDataError{Offset: 42, Err: govalidator.ErrTrailingTokens},
+			wantPointer: "",
+			wantRule:    "parse",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// act
+			converted := FromError(tt.err)
+
+			// assert
+			require.Len(t, converted.Violations, 1)
+			require.Equal(t, tt.wantPointer, converted.Violations[0].Pointer)
+			require.Equal(t, tt.wantRule, converted.Violations[0].Rule)
+			require.Equal(t, LocationBody, converted.Violations[0].Location)
+		})
+	}
+}
+
+type goParams struct {
+	Limit     int    `query:"limit" validate:"max=100"`
+	RequestID string `header:"X-Request-Id" validate:"required"`
+}
+
+func TestFromGoParamErrors(t *testing.T) {
+	ctx := context.Background()
+	v := govalidator.NewValidator()
+
+	tests := []struct {
+		name           string
+		url            string
+		wantViolations []Violation
+		wantStatus     int
+	}{
+		{
+			name: "given params that fail their tags, when we convert the error, a violation per param should be returned in its location",
+			url:  "/members?limit=500",
+			wantViolations: []Violation{
+				{Pointer: "/limit", Rule: "max", Value: 500, Location: LocationQuery},
+				{Pointer: "/X-Request-Id", Rule: "required", Location: LocationHeader},
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "given a param that can't be converted, when we convert the error, a parse violation should be returned",
+			url:  "/members?limit=ten",
+			wantViolations: []Violation{
+				{Pointer: "/limit", Rule: "parse", Value: "ten", Location: LocationQuery},
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			r, err := http.NewRequest(http.MethodGet, tt.url, nil)
+			require.NoError(t, err)
+			err = v.ValidateParams(ctx, r, &goParams{})
+
+			// act
+			converted := FromError(err)
+
+			// assert
+			require.Len(t, converted.Violations, len(tt.wantViolations))
+			for i, want := range tt.wantViolations {
+				got := converted.Violations[i]
+				got.Message = ""
+				require.Equal(t, want, got)
+			}
+			require.Equal(t, tt.wantStatus, StatusCode(err))
+		})
+	}
+}
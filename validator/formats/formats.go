@@ -0,0 +1,345 @@
+// Package formats implements the string formats shared by every request validator of
+// this project, so the same format name means the same thing whether it is set in the
+// OpenAPI document or in the validate tag of a Go struct.
+package formats
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Names of the formats of the library.
+const (
+	UUID         = "uuid"
+	Email        = "email"
+	Hostname     = "hostname"
+	IDNHostname  = "idn-hostname"
+	IPv4         = "ipv4"
+	IPv6         = "ipv6"
+	URI          = "uri"
+	URIReference = "uri-reference"
+	Date         = "date"
+	DateTime     = "date-time"
+	Duration     = "duration"
+	E164         = "e164"
+	Country      = "iso3166-alpha2"
+	Currency     = "iso4217"
+	Byte         = "byte"
+	Base64       = "base64"
+	ULID         = "ulid"
+)
+
+// Func checks if a string follows a format and returns the reason why it doesn't otherwise.
+// It implements the openapi3.StringFormatValidator interface of the kin-openapi library.
+type Func func(value string) error
+
+// Validate calls f(value).
+func (f Func) Validate(value string) error {
+	return f(value)
+}
+
+var library = map[string]Func{
+	UUID:         validateUUID,
+	Email:        validateEmail,
+	Hostname:     func(value string) error { return validateHostname(value, false) },
+	IDNHostname:  func(value string) error { return validateHostname(value, true) },
+	IPv4:         validateIPv4,
+	IPv6:         validateIPv6,
+	URI:          validateURI,
+	URIReference: validateURIReference,
+	Date:         validateDate,
+	DateTime:     validateDateTime,
+	Duration:     validateDuration,
+	E164:         validateE164,
+	Country:      validateCountry,
+	Currency:     validateCurrency,
+	Byte:         validateBase64,
+	Base64:       validateBase64,
+	ULID:         validateULID,
+}
+
+// Lookup returns the validator of the format with the given name.
+func Lookup(name string) (Func, bool) {
+	f, ok := library[name]
+	return f, ok
+}
+
+// All returns the validators of every format of the library by name.
+func All() map[string]Func {
+	all := make(map[string]Func, len(library))
+	for name, f := range library {
+		all[name] = f
+	}
+	return all
+}
+
+var (
+	uuidRegex     = regexp.MustCompile(`^(?:[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[89abAB][0-9a-fA-F]{3}-[0-9a-fA-F]{12}|00000000-0000-0000-0000-000000000000)$`)
+	durationRegex = regexp.MustCompile(`^P(?:\d+Y)?(?:\d+M)?(?:\d+W)?(?:\d+D)?(?:T(?:\d+H)?(?:\d+M)?(?:\d+(?:[.,]\d+)?S)?)?$`)
+	e164Regex     = regexp.MustCompile(`^\+[1-9][0-9]{1,14}$`)
+	ulidRegex     = regexp.MustCompile(`^[0-7][0-9A-HJKMNP-TV-Za-hjkmnp-tv-z]{25}$`)
+)
+
+// validateUUID accepts the UUIDs with the variant of RFC 4122, whatever their version so the
+// ones of RFC 9562 (6 to 8) are accepted too, and the nil UUID.
+func validateUUID(value string) error {
+	if !uuidRegex.MatchString(value) {
+		return errors.New("not an RFC 9562 UUID")
+	}
+	return nil
+}
+
+// validateEmail accepts the addresses of RFC 5322, without comments nor folding white
+// spaces, whose local part and domain may hold UTF-8 characters as allowed by RFC 6531.
+func validateEmail(value string) error {
+	if len(value) > 254 {
+		return errors.New("the address is longer than 254 characters")
+	}
+	at := strings.LastIndexByte(value, '@')
+	if at < 0 {
+		return errors.New("the address has no @ sign")
+	}
+	local, domain := value[:at], value[at+1:]
+
+	if err := validateLocalPart(local); err != nil {
+		return err
+	}
+	if strings.HasPrefix(domain, "[") && strings.HasSuffix(domain, "]") {
+		return validateDomainLiteral(domain[1 : len(domain)-1])
+	}
+	return validateHostname(domain, true)
+}
+
+func validateLocalPart(local string) error {
+	switch {
+	case local == "":
+		return errors.New("the local part is empty")
+	case len(local) > 64:
+		return errors.New("the local part is longer than 64 characters")
+	case len(local) >= 2 && local[0] == '"' && local[len(local)-1] == '"':
+		return validateQuotedString(local[1 : len(local)-1])
+	}
+
+	for _, atom := range strings.Split(local, ".") {
+		if atom == "" {
+			return errors.New("the local part has an empty dot separated atom")
+		}
+		for _, r := range atom {
+			if !isAtext(r) {
+				return fmt.Errorf("the local part has the invalid character %q", r)
+			}
+		}
+	}
+	return nil
+}
+
+func validateQuotedString(quoted string) error {
+	escaped := false
+	for _, r := range quoted {
+		switch {
+		case escaped:
+			if r < ' ' || r == 0x7f {
+				return fmt.Errorf("the local part has the invalid escaped character %q", r)
+			}
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '"' || r < ' ' || r == 0x7f:
+			return fmt.Errorf("the local part has the invalid quoted character %q", r)
+		}
+	}
+	if escaped {
+		return errors.New("the local part ends with an escape character")
+	}
+	return nil
+}
+
+func isAtext(r rune) bool {
+	switch {
+	case r >= utf8.RuneSelf:
+		return unicode.IsPrint(r) && !unicode.IsSpace(r)
+	case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		return true
+	default:
+		return strings.ContainsRune("!#$%&'*+-/=?^_`{|}~", r)
+	}
+}
+
+func validateDomainLiteral(literal string) error {
+	if ip := strings.TrimPrefix(literal, "IPv6:"); ip != literal {
+		return validateIPv6(ip)
+	}
+	return validateIPv4(literal)
+}
+
+// validateHostname accepts the host names of RFC 1123 and, when idn is set, the
+// internationalized ones whose labels hold unicode letters and digits.
+func validateHostname(value string, idn bool) error {
+	host := strings.TrimSuffix(value, ".")
+	switch {
+	case host == "":
+		return errors.New("the host name is empty")
+	case len(host) > 253:
+		return errors.New("the host name is longer than 253 characters")
+	}
+
+	labels := strings.Split(host, ".")
+	for _, label := range labels {
+		switch {
+		case label == "":
+			return errors.New("the host name has an empty label")
+		case utf8.RuneCountInString(label) > 63:
+			return fmt.Errorf("the label %q is longer than 63 characters", label)
+		case label[0] == '-' || label[len(label)-1] == '-':
+			return fmt.Errorf("the label %q starts or ends with a hyphen", label)
+		}
+		for _, r := range label {
+			if !isLabelRune(r, idn) {
+				return fmt.Errorf("the label %q has the invalid character %q", label, r)
+			}
+		}
+	}
+	if tld := labels[len(labels)-1]; strings.Trim(tld, "0123456789") == "" {
+		return fmt.Errorf("the top level label %q is numeric", tld)
+	}
+	return nil
+}
+
+func isLabelRune(r rune, idn bool) bool {
+	switch {
+	case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-':
+		return true
+	case r >= utf8.RuneSelf && idn:
+		return unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.Is(unicode.Mn, r)
+	default:
+		return false
+	}
+}
+
+func validateIPv4(value string) error {
+	if ip := net.ParseIP(value); ip == nil || ip.To4() == nil || strings.Contains(value, ":") {
+		return errors.New("not an IPv4 address")
+	}
+	return nil
+}
+
+func validateIPv6(value string) error {
+	if ip := net.ParseIP(value); ip == nil || !strings.Contains(value, ":") {
+		return errors.New("not an IPv6 address")
+	}
+	return nil
+}
+
+// validateURI accepts the absolute URIs of RFC 3986.
+func validateURI(value string) error {
+	u, err := parseURIReference(value)
+	if err != nil {
+		return err
+	}
+	if u.Scheme == "" {
+		return errors.New("the URI has no scheme")
+	}
+	return nil
+}
+
+// validateURIReference accepts the absolute and relative URIs of RFC 3986.
+func validateURIReference(value string) error {
+	_, err := parseURIReference(value)
+	return err
+}
+
+func parseURIReference(value string) (*url.URL, error) {
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case c == '%':
+			if i+2 >= len(value) || !isHex(value[i+1]) || !isHex(value[i+2]) {
+				return nil, errors.New("the URI has an invalid percent encoding")
+			}
+			i += 2
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case strings.IndexByte("-._~:/?#[]@!$&'()*+,;=", c) >= 0:
+		default:
+			return nil, fmt.Errorf("the URI has the invalid character %q", c)
+		}
+	}
+	u, err := url.Parse(value)
+	if err != nil {
+		return nil, errors.Unwrap(err)
+	}
+	return u, nil
+}
+
+func isHex(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F'
+}
+
+// validateDate accepts the full-date of RFC 3339, such as "2017-07-21".
+func validateDate(value string) error {
+	if _, err := time.Parse("2006-01-02", value); err != nil {
+		return errors.New("not an RFC 3339 date")
+	}
+	return nil
+}
+
+// validateDateTime accepts the date-time of RFC 3339, such as "2017-07-21T17:32:28Z".
+func validateDateTime(value string) error {
+	if _, err := time.Parse(time.RFC3339Nano, strings.ToUpper(value)); err != nil {
+		return errors.New("not an RFC 3339 date-time")
+	}
+	return nil
+}
+
+// validateDuration accepts the ISO 8601 durations, such as "P1Y2M10DT2H30M".
+func validateDuration(value string) error {
+	if value == "P" || strings.HasSuffix(value, "T") || !durationRegex.MatchString(value) {
+		return errors.New("not an ISO 8601 duration")
+	}
+	return nil
+}
+
+// validateE164 accepts the phone numbers of ITU-T E.164, such as "+14155552671".
+func validateE164(value string) error {
+	if !e164Regex.MatchString(value) {
+		return errors.New("not an E.164 phone number")
+	}
+	return nil
+}
+
+func validateCountry(value string) error {
+	if _, ok := countries[value]; !ok {
+		return errors.New("not an ISO 3166-1 alpha-2 country code")
+	}
+	return nil
+}
+
+func validateCurrency(value string) error {
+	if _, ok := currencies[value]; !ok {
+		return errors.New("not an ISO 4217 currency code")
+	}
+	return nil
+}
+
+// validateBase64 accepts the padded base64 encoding of RFC 4648.
+func validateBase64(value string) error {
+	if _, err := base64.StdEncoding.DecodeString(value); err != nil {
+		return errors.New("not a base64 encoded string")
+	}
+	return nil
+}
+
+// validateULID accepts the ULIDs, whose 26 characters use Crockford's base32 alphabet.
+func validateULID(value string) error {
+	if !ulidRegex.MatchString(value) {
+		return errors.New("not a ULID")
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package formats
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormats(t *testing.T) {
+	tests := []struct {
+		format  string
+		valid   []string
+		invalid []string
+	}{
+		{
+			format:  UUID,
+			valid:   []string{"32d3e8f1-2f81-49c0-acb6-6dccd84f3dab", "018f6b1e-7c1a-7d3e-9a4b-1c2d3e4f5a6b", "00000000-0000-0000-0000-000000000000"},
+			invalid: []string{"", "sadwefsds", "32d3e8f1-2f81-49c0-ccb6-6dccd84f3dab"},
+		},
+		{
+			format: Email,
+			valid: []string{
+				"jon_snow@winterfell.com",
+				"Jon.Snow@Winterfell.COM",
+				"jon+night.watch@castle-black.north.museum",
+				`"jon snow"@winterfell.com`,
+				"jon@[127.0.0.1]",
+				"jon@[IPv6:::1]",
+				"jon@localhost",
+				"jön@wïnterfell.中国",
+			},
+			invalid: []string{
+				"",
+				"this_is_a_test",
+				"@winterfell.com",
+				"jon@",
+				"jon..snow@winterfell.com",
+				"jon snow@winterfell.com",
+				"jon@-winterfell.com",
+				"jon@winterfell..com",
+				"jon@[999.0.0.1]",
+				"jon@winterfell.123",
+			},
+		},
+		{
+			format:  Hostname,
+			valid:   []string{"winterfell", "api.example.com", "api.example.com.", "xn--wnterfell-p8a.com"},
+			invalid: []string{"", "-api.example.com", "api_example.com", "wïnterfell.com"},
+		},
+		{
+			format:  IDNHostname,
+			valid:   []string{"wïnterfell.com", "例え.テスト"},
+			invalid: []string{"", "wïnterfell-.com", "wïnter fell.com"},
+		},
+		{
+			format:  IPv4,
+			valid:   []string{"127.0.0.1", "192.168.1.254"},
+			invalid: []string{"", "256.0.0.1", "127.0.0", "::1", "::ffff:127.0.0.1"},
+		},
+		{
+			format:  IPv6,
+			valid:   []string{"::1", "2001:db8::8a2e:370:7334", "::ffff:127.0.0.1"},
+			invalid: []string{"", "127.0.0.1", "2001:db8:::1"},
+		},
+		{
+			format:  URI,
+			valid:   []string{"http://api.example.com/v1/users?id=1#top", "urn:isbn:0451450523", "mailto:jon@winterfell.com"},
+			invalid: []string{"", "/v1/users", "http://api.example.com/jon snow", "http://api.example.com/%zz"},
+		},
+		{
+			format:  URIReference,
+			valid:   []string{"", "/v1/users", "../users?id=1", "http://api.example.com"},
+			invalid: []string{"/jon snow", "/users/%2", "/users/<id>"},
+		},
+		{
+			format:  Date,
+			valid:   []string{"2017-07-21", "2024-02-29"},
+			invalid: []string{"", "2017-7-21", "2023-02-29", "2017-13-01", "21/07/2017"},
+		},
+		{
+			format:  DateTime,
+			valid:   []string{"2017-07-21T17:32:28Z", "2017-07-21T17:32:28.123+02:00", "2017-07-21t17:32:28z"},
+			invalid: []string{"", "2017-07-21", "2017-07-21T17:32:28", "2017-07-21T25:32:28Z"},
+		},
+		{
+			format:  Duration,
+			valid:   []string{"P1Y2M10DT2H30M", "PT1.5S", "P3W", "PT36H"},
+			invalid: []string{"", "P", "PT", "P1H", "1Y", "P1DT"},
+		},
+		{
+			format:  E164,
+			valid:   []string{"+14155552671", "+442071838750"},
+			invalid: []string{"", "14155552671", "+04155552671", "+1415555267123456"},
+		},
+		{
+			format:  Country,
+			valid:   []string{"ES", "US", "JP"},
+			invalid: []string{"", "es", "UK", "ESP"},
+		},
+		{
+			format:  Currency,
+			valid:   []string{"EUR", "USD", "JPY"},
+			invalid: []string{"", "eur", "EU", "ABC"},
+		},
+		{
+			format:  Byte,
+			valid:   []string{"", "U3dhZ2dlciByb2Nrcw=="},
+			invalid: []string{"U3dhZ2dlciByb2Nrcw", "not base64!"},
+		},
+		{
+			format:  ULID,
+			valid:   []string{"01ARZ3NDEKTSV4RRFFQ69G5FAV", "01arz3ndektsv4rrffq69g5fav"},
+			invalid: []string{"", "81ARZ3NDEKTSV4RRFFQ69G5FAV", "01ARZ3NDEKTSV4RRFFQ69G5FAI", "01ARZ3NDEKTSV4RRFFQ69G5FA"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			// arrange
+			validate, ok := Lookup(tt.format)
+			require.True(t, ok, "the format should be in the library")
+
+			// act & assert
+			for _, value := range tt.valid {
+				require.NoError(t, validate(value), "%q should be a valid %s", value, tt.format)
+			}
+			for _, value := range tt.invalid {
+				require.Error(t, validate(value), "%q should not be a valid %s", value, tt.format)
+			}
+		})
+	}
+}
+
+func TestAll(t *testing.T) {
+	// arrange
+	all := All()
+	delete(all, Email)
+
+	// act
+	_, ok := Lookup(Email)
+
+	// assert
+	require.True(t, ok, "changing the returned formats should not change the library")
+}
@@ -0,0 +1,78 @@
+package govalidator
+
+import (
+	"context"
+	"net/http"
+)
+
+// Bind decodes the request body into a new value of type T and validates it.
+func Bind[T any](ctx context.Context, v *Validator, r *http.Request) (*T, error) {
+	req := new(T)
+	if err := v.ValidateRequest(ctx, r, req); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// BindParams binds the params of the request into a new value of type T and validates it.
+func BindParams[T any](ctx context.Context, v *Validator, r *http.Request) (*T, error) {
+	params := new(T)
+	if err := v.ValidateParams(ctx, r, params); err != nil {
+		return nil, err
+	}
+	return params, nil
+}
+
+// ErrorResponder writes the response of a request that failed the validation.
+type ErrorResponder func(w http.ResponseWriter, r *http.Request, err error)
+
+// MiddlewareOption configures the behaviour of the binding middleware.
+type MiddlewareOption func(*middleware)
+
+// WithErrorResponder sets the function used to reject the invalid requests.
+func WithErrorResponder(responder ErrorResponder) MiddlewareOption {
+	return func(m *middleware) {
+		m.responder = responder
+	}
+}
+
+type middleware struct {
+	responder ErrorResponder
+}
+
+// BindMiddleware returns an http middleware that binds every request body into a new
+// value of type T before forwarding the request to the next handler. The typed body is
+// stored in the request context and can be recovered with BodyFromContext.
+func BindMiddleware[T any](v *Validator, opts ...MiddlewareOption) func(http.Handler) http.Handler {
+	m := &middleware{responder: DefaultErrorResponder}
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := Bind[T](r.Context(), v, r)
+			if err != nil {
+				m.responder(w, r, err)
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), bodyContextKey[T]{}, body)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// DefaultErrorResponder rejects the invalid requests with a 400, writing the validation
+// error as plain text.
+func DefaultErrorResponder(w http.ResponseWriter, r *http.Request, err error) {
+	http.Error(w, err.Error(), http.StatusBadRequest)
+}
+
+type bodyContextKey[T any] struct{}
+
+// BodyFromContext returns the request body bound by the middleware of the same type T.
+func BodyFromContext[T any](ctx context.Context) (*T, bool) {
+	body, ok := ctx.Value(bodyContextKey[T]{}).(*T)
+	return body, ok
+}
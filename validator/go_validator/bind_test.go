@@ -0,0 +1,117 @@
+package govalidator
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-playground/validator"
+	"github.com/stretchr/testify/require"
+
+	api "request_validator/http/v2"
+)
+
+func TestBind(t *testing.T) {
+	// create the validator
+	ctx := context.Background()
+	reqValidator := NewValidator()
+
+	tests := []struct {
+		name     string
+		req      string
+		wantFunc func(t *testing.T, err error, req *api.CreateUserReq)
+	}{
+		{
+			name: "given a request that does not have a required field specified, when we bind it, an error should be returned",
+			req:  missingMandatoryFieldRequest,
+			wantFunc: func(t *testing.T, err error, req *api.CreateUserReq) {
+				var validationErrors validator.ValidationErrors
+				require.True(t, errors.As(err, &validationErrors), "error should be of type validator.ValidationErrors")
+				require.Nil(t, req)
+			},
+		},
+		{
+			name: "given a valid request, when we bind it, the typed request should be returned",
+			req:  correctRequest,
+			wantFunc: func(t *testing.T, err error, req *api.CreateUserReq) {
+				require.NoError(t, err, "bind should not error")
+				require.Equal(t, "32d3e8f1-2f81-49c0-acb6-6dccd84f3dab", req.Id)
+				require.Equal(t, "Jon", req.FirstName)
+				require.Equal(t, "Snow", req.LastName)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, "", bytes.NewReader([]byte(tt.req)))
+			require.NoError(t, err, "http request creation should not error")
+			httpRequest.Header.Add("Content-Type", "application/json")
+
+			// act
+			req, err := Bind[api.CreateUserReq](ctx, &reqValidator, httpRequest)
+
+			// assert
+			tt.wantFunc(t, err, req)
+		})
+	}
+}
+
+func TestBindMiddleware(t *testing.T) {
+	// create the validator
+	ctx := context.Background()
+	reqValidator := NewValidator()
+
+	tests := []struct {
+		name       string
+		req        string
+		opts       []MiddlewareOption
+		wantStatus int
+	}{
+		{
+			name:       "given a valid request, when it goes through the middleware, the typed body should be in the context",
+			req:        correctRequest,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "given an invalid request, when it goes through the middleware, it should be rejected",
+			req:        invalidFormatFieldRequest,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "given an invalid request and a custom error responder, when it goes through the middleware, the custom responder should reject it",
+			req:  invalidFormatFieldRequest,
+			opts: []MiddlewareOption{WithErrorResponder(func(w http.ResponseWriter, r *http.Request, err error) {
+				w.WriteHeader(http.StatusUnprocessableEntity)
+			})},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, "", bytes.NewReader([]byte(tt.req)))
+			require.NoError(t, err, "http request creation should not error")
+			httpRequest.Header.Add("Content-Type", "application/json")
+			recorder := httptest.NewRecorder()
+
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				req, ok := BodyFromContext[api.CreateUserReq](r.Context())
+				require.True(t, ok, "typed body should be in the context")
+				require.Equal(t, "32d3e8f1-2f81-49c0-acb6-6dccd84f3dab", req.Id)
+				w.WriteHeader(http.StatusOK)
+			})
+
+			// act
+			BindMiddleware[api.CreateUserReq](&reqValidator, tt.opts...)(next).ServeHTTP(recorder, httpRequest)
+
+			// assert
+			require.Equal(t, tt.wantStatus, recorder.Code)
+		})
+	}
+}
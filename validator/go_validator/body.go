@@ -0,0 +1,74 @@
+package govalidator
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// ErrBodyTooLarge is returned when the request body is bigger than the limit of the validator.
+var ErrBodyTooLarge = errors.New("request body too large")
+
+// bufferedBody is the request body restored by the validator, which keeps the raw bytes so
+// they can be read again without buffering them twice.
+type bufferedBody struct {
+	*bytes.Reader
+	data []byte
+}
+
+func (b *bufferedBody) Close() error {
+	return nil
+}
+
+// RawBody returns the raw bytes of the request body buffered by the validator.
+func RawBody(r *http.Request) ([]byte, bool) {
+	body, ok := r.Body.(*bufferedBody)
+	if !ok {
+		return nil, false
+	}
+	return body.data, true
+}
+
+// readBody buffers the request body up to the size limit and restores it, so the next
+// handlers can read it again.
+func (v *Validator) readBody(r *http.Request) ([]byte, error) {
+	if body, ok := r.Body.(*bufferedBody); ok {
+		setBody(r, body.data)
+		return body.data, nil
+	}
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil, nil
+	}
+
+	reader := io.Reader(r.Body)
+	if v.options.maxBodySize > 0 {
+		reader = io.LimitReader(r.Body, v.options.maxBodySize+1)
+	}
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read request body: %w", err)
+	}
+
+	if v.options.maxBodySize > 0 && int64(len(data)) > v.options.maxBodySize {
+		// put back what was read in front of the rest of the body
+		r.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(data), r.Body), r.Body}
+		return nil, fmt.Errorf("%w: the limit is %d bytes", ErrBodyTooLarge, v.options.maxBodySize)
+	}
+
+	r.Body.Close()
+	setBody(r, data)
+	return data, nil
+}
+
+func setBody(r *http.Request, data []byte) {
+	r.Body = &bufferedBody{Reader: bytes.NewReader(data), data: data}
+	r.ContentLength = int64(len(data))
+	r.GetBody = func() (io.ReadCloser, error) {
+		return &bufferedBody{Reader: bytes.NewReader(data), data: data}, nil
+	}
+}
@@ -0,0 +1,110 @@
+package govalidator
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	api "request_validator/http/v2"
+)
+
+func TestRequestBody(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		opts     []Option
+		req      string
+		wantFunc func(t *testing.T, err error, r *http.Request)
+	}{
+		{
+			name: "given a valid request, when we try to validate it, its body should be restored and its raw bytes exposed",
+			req:  correctRequest,
+			wantFunc: func(t *testing.T, err error, r *http.Request) {
+				require.NoError(t, err, "validator should not error")
+				raw, ok := RawBody(r)
+				require.True(t, ok, "the raw body should be exposed")
+				require.Equal(t, correctRequest, string(raw))
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err, "the restored body should be readable")
+				require.Equal(t, correctRequest, string(body))
+				getBody, err := r.GetBody()
+				require.NoError(t, err, "the body should be available through GetBody")
+				body, err = io.ReadAll(getBody)
+				require.NoError(t, err, "the body of GetBody should be readable")
+				require.Equal(t, correctRequest, string(body))
+			},
+		},
+		{
+			name: "given an invalid request, when we try to validate it, its body should be restored",
+			req:  invalidFormatFieldRequest,
+			wantFunc: func(t *testing.T, err error, r *http.Request) {
+				require.Error(t, err, "validator should error")
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err, "the restored body should be readable")
+				require.Equal(t, invalidFormatFieldRequest, string(body))
+			},
+		},
+		{
+			name: "given a request bigger than the size limit, when we try to validate it, an error should be returned and its body left unread",
+			opts: []Option{WithMaxBodySize(16)},
+			req:  correctRequest,
+			wantFunc: func(t *testing.T, err error, r *http.Request) {
+				require.True(t, errors.Is(err, ErrBodyTooLarge), "error should be ErrBodyTooLarge")
+				_, ok := RawBody(r)
+				require.False(t, ok, "the raw body should not be exposed")
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err, "the body should be readable")
+				require.Equal(t, correctRequest, string(body))
+			},
+		},
+		{
+			name: "given a request bigger than the default size limit and no limit, when we try to validate it, no error should be returned",
+			opts: []Option{WithMaxBodySize(0)},
+			req:  `{"id": "32d3e8f1-2f81-49c0-acb6-6dccd84f3dab", "firstName": "` + string(bytes.Repeat([]byte("a"), DefaultMaxBodySize)) + `", "lastName": "Snow"}`,
+			wantFunc: func(t *testing.T, err error, r *http.Request) {
+				require.NoError(t, err, "validator should not error")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			reqValidator := NewValidator(tt.opts...)
+			httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, "", bytes.NewReader([]byte(tt.req)))
+			require.NoError(t, err, "http request creation should not error")
+			httpRequest.Header.Add("Content-Type", "application/json")
+
+			// act
+			var req api.CreateUserReq
+			err = reqValidator.ValidateRequest(ctx, httpRequest, &req)
+
+			// assert
+			tt.wantFunc(t, err, httpRequest)
+		})
+	}
+}
+
+func TestRequestBodyIsValidatedTwice(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	reqValidator := NewValidator()
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, "", bytes.NewReader([]byte(correctRequest)))
+	require.NoError(t, err, "http request creation should not error")
+	httpRequest.Header.Add("Content-Type", "application/json")
+	var first, second api.CreateUserReq
+	require.NoError(t, reqValidator.ValidateRequest(ctx, httpRequest, &first), "first validation should not error")
+
+	// act
+	err = reqValidator.ValidateRequest(ctx, httpRequest, &second)
+
+	// assert
+	require.NoError(t, err, "second validation should not error")
+	require.Equal(t, first, second)
+}
@@ -0,0 +1,178 @@
+package govalidator
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrEmptyBody is returned when the request has no body, or a body with only white spaces.
+	ErrEmptyBody = errors.New("empty request body")
+	// ErrTrailingTokens is wrapped by the TrailingDataError of the request bodies with more
+	// than one JSON document, when the trailing tokens are disallowed.
+	ErrTrailingTokens = errors.New("unexpected JSON document")
+)
+
+// InvalidTargetError is returned when the value the request body should be decoded into is
+// not a non-nil pointer to a struct. It is a programming error rather than a bad request.
+type InvalidTargetError struct {
+	// Type is the type of the target, nil if the target is nil.
+	Type reflect.Type
+}
+
+func (e *InvalidTargetError) Error() string {
+	switch {
+	case e.Type == nil:
+		return "invalid target: nil"
+	case e.Type.Kind() != reflect.Ptr:
+		return fmt.Sprintf("invalid target: non-pointer %s", e.Type)
+	case e.Type.Elem().Kind() != reflect.Struct:
+		return fmt.Sprintf("invalid target: pointer to non-struct %s", e.Type)
+	default:
+		return fmt.Sprintf("invalid target: nil %s", e.Type)
+	}
+}
+
+// checkTarget returns an InvalidTargetError if target is not a non-nil pointer to a struct.
+func checkTarget(target interface{}) error {
+	value := reflect.ValueOf(target)
+	if !value.IsValid() {
+		return &InvalidTargetError{}
+	}
+	if value.Kind() != reflect.Ptr || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return &InvalidTargetError{Type: value.Type()}
+	}
+	return nil
+}
+
+// TrailingDataError is returned when the JSON document of the request body is followed by
+// data that is not JSON.
+type TrailingDataError struct {
+	// Offset is the position in the body where the trailing data starts.
+	Offset int64
+	Err    error
+}
+
+func (e *TrailingDataError) Error() string {
+	return fmt.Sprintf("invalid data after the JSON document at offset %d: %v", e.Offset, e.Err)
+}
+
+func (e *TrailingDataError) Unwrap() error {
+	return e.Err
+}
+
+// TypeMismatchError is returned when a value of the request body can't be decoded into the
+// type of its field.
+type TypeMismatchError struct {
+	// Field is the dotted path of the JSON field, such as "items.3.email", empty for the top
+	// level value.
+	Field string
+	// Value is the JSON type of the value, such as "string" or "number".
+	Value string
+	// Type is the Go type of the field.
+	Type reflect.Type
+	Err  *json.UnmarshalTypeError
+}
+
+func (e *TypeMismatchError) Error() string {
+	if e.Field == "" {
+		return fmt.Sprintf("the request body must be of type %s, got %s", e.Type, e.Value)
+	}
+	return fmt.Sprintf("field %q must be of type %s, got %s", e.Field, e.Type, e.Value)
+}
+
+func (e *TypeMismatchError) Unwrap() error {
+	return e.Err
+}
+
+// UnknownFieldError is returned when the request body has a field that is not in the struct
+// it is decoded into, when the unknown fields are disallowed.
+type UnknownFieldError struct {
+	// Field is the name of the JSON field. The decoder doesn't report the path of the field.
+	Field string
+}
+
+func (e *UnknownFieldError) Error() string {
+	return fmt.Sprintf("unknown field %q", e.Field)
+}
+
+// DuplicateKeyError is returned when an object of the request body has the same key twice,
+// when the duplicate keys are disallowed.
+type DuplicateKeyError struct {
+	// Field is the dotted path of the duplicate key, such as "items.3.email".
+	Field string
+}
+
+func (e *DuplicateKeyError) Error() string {
+	return fmt.Sprintf("duplicate field %q", e.Field)
+}
+
+// unknownFieldPrefix is the prefix of the errors of the JSON decoder for the unknown fields.
+const unknownFieldPrefix = "json: unknown field "
+
+// decodeError converts the errors of the JSON decoder into the typed errors of the validator.
+func decodeError(err error) error {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return &TypeMismatchError{Field: typeErr.Field, Value: typeErr.Value, Type: typeErr.Type, Err: typeErr}
+	}
+	if msg := err.Error(); strings.HasPrefix(msg, unknownFieldPrefix) {
+		if field, unquoteErr := strconv.Unquote(strings.TrimPrefix(msg, unknownFieldPrefix)); unquoteErr == nil {
+			return &UnknownFieldError{Field: field}
+		}
+	}
+	return fmt.Errorf("unable to unmarshal request body: %w", err)
+}
+
+// checkDuplicateKeys returns a DuplicateKeyError if an object of the first JSON document of
+// the body has the same key twice. The syntax errors are left to the decoding of the body.
+func checkDuplicateKeys(body []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	return checkValueKeys(decoder, nil)
+}
+
+func checkValueKeys(decoder *json.Decoder, path []string) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil
+	}
+	delim, ok := token.(json.Delim)
+	if !ok {
+		return nil
+	}
+
+	switch delim {
+	case '{':
+		keys := make(map[string]struct{})
+		for decoder.More() {
+			token, err := decoder.Token()
+			if err != nil {
+				return nil
+			}
+			key, _ := token.(string)
+			keyPath := append(append([]string(nil), path...), key)
+			if _, duplicate := keys[key]; duplicate {
+				return &DuplicateKeyError{Field: strings.Join(keyPath, ".")}
+			}
+			keys[key] = struct{}{}
+			if err := checkValueKeys(decoder, keyPath); err != nil {
+				return err
+			}
+		}
+	case '[':
+		for i := 0; decoder.More(); i++ {
+			if err := checkValueKeys(decoder, append(append([]string(nil), path...), strconv.Itoa(i))); err != nil {
+				return err
+			}
+		}
+	}
+	// consume the closing delimiter
+	_, _ = decoder.Token()
+	return nil
+}
@@ -0,0 +1,167 @@
+package govalidator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	api "request_validator/http/v2"
+)
+
+type createTeamReq struct {
+	Name    string              `json:"name" validate:"required"`
+	Members []api.CreateUserReq `json:"members" validate:"dive"`
+}
+
+func TestValidateRequestErrors(t *testing.T) {
+	// create the validator
+	ctx := context.Background()
+	reqValidator := NewValidator()
+
+	var nilUser *api.CreateUserReq
+	tests := []struct {
+		name     string
+		req      string
+		target   interface{}
+		wantFunc func(t *testing.T, err error)
+	}{
+		{
+			name:   "given a nil target, when we try to validate the request, an invalid target error should be returned",
+			req:    correctRequest,
+			target: nil,
+			wantFunc: func(t *testing.T, err error) {
+				var targetErr *InvalidTargetError
+				require.True(t, errors.As(err, &targetErr), "error should be of type InvalidTargetError")
+				require.Nil(t, targetErr.Type)
+			},
+		},
+		{
+			name:   "given a non-pointer target, when we try to validate the request, an invalid target error should be returned",
+			req:    correctRequest,
+			target: api.CreateUserReq{},
+			wantFunc: func(t *testing.T, err error) {
+				var targetErr *InvalidTargetError
+				require.True(t, errors.As(err, &targetErr), "error should be of type InvalidTargetError")
+				require.Equal(t, reflect.TypeOf(api.CreateUserReq{}), targetErr.Type)
+				require.Contains(t, err.Error(), "non-pointer")
+			},
+		},
+		{
+			name:   "given a nil pointer target, when we try to validate the request, an invalid target error should be returned",
+			req:    correctRequest,
+			target: nilUser,
+			wantFunc: func(t *testing.T, err error) {
+				var targetErr *InvalidTargetError
+				require.True(t, errors.As(err, &targetErr), "error should be of type InvalidTargetError")
+				require.Contains(t, err.Error(), "nil *http_v2.CreateUserReq")
+			},
+		},
+		{
+			name:   "given a pointer to a non-struct target, when we try to validate the request, an invalid target error should be returned",
+			req:    correctRequest,
+			target: &map[string]interface{}{},
+			wantFunc: func(t *testing.T, err error) {
+				var targetErr *InvalidTargetError
+				require.True(t, errors.As(err, &targetErr), "error should be of type InvalidTargetError")
+				require.Contains(t, err.Error(), "pointer to non-struct")
+			},
+		},
+		{
+			name:   "given a request without body, when we try to validate it, an empty body error should be returned",
+			req:    "",
+			target: &api.CreateUserReq{},
+			wantFunc: func(t *testing.T, err error) {
+				require.True(t, errors.Is(err, ErrEmptyBody), "error should be ErrEmptyBody")
+			},
+		},
+		{
+			name:   "given a request with a white space body, when we try to validate it, an empty body error should be returned",
+			req:    " \n\t ",
+			target: &api.CreateUserReq{},
+			wantFunc: func(t *testing.T, err error) {
+				require.True(t, errors.Is(err, ErrEmptyBody), "error should be ErrEmptyBody")
+			},
+		},
+		{
+			name:   "given a request with garbage after the JSON document, when we try to validate it, a trailing data error should be returned",
+			req:    correctRequest + "\n  garbage",
+			target: &api.CreateUserReq{},
+			wantFunc: func(t *testing.T, err error) {
+				var trailingErr *TrailingDataError
+				require.True(t, errors.As(err, &trailingErr), "error should be of type TrailingDataError")
+				require.Equal(t, int64(len(correctRequest)+3), trailingErr.Offset)
+			},
+		},
+		{
+			name:     "given a request with white spaces after the JSON document, when we try to validate it, no error should be returned",
+			req:      correctRequest + "\n\n",
+			target:   &api.CreateUserReq{},
+			wantFunc: func(t *testing.T, err error) { require.NoError(t, err, "validator should not error") },
+		},
+		{
+			name:   "given a request with a malformed JSON document, when we try to validate it, a syntax error should be returned",
+			req:    `{"id": "32d3e8f1-2f81-49c0-acb6-6dccd84f3dab", "firstName: "Jon"}`,
+			target: &api.CreateUserReq{},
+			wantFunc: func(t *testing.T, err error) {
+				var syntaxErr *json.SyntaxError
+				require.True(t, errors.As(err, &syntaxErr), "error should be of type json.SyntaxError")
+			},
+		},
+		{
+			name:   "given a request with a field of the wrong type, when we try to validate it, a type mismatch error with the field should be returned",
+			req:    `{"id": 42, "firstName": "Jon", "lastName": "Snow"}`,
+			target: &api.CreateUserReq{},
+			wantFunc: func(t *testing.T, err error) {
+				var mismatchErr *TypeMismatchError
+				require.True(t, errors.As(err, &mismatchErr), "error should be of type TypeMismatchError")
+				require.Equal(t, "id", mismatchErr.Field)
+				require.Equal(t, "number", mismatchErr.Value)
+				require.Equal(t, reflect.TypeOf(""), mismatchErr.Type)
+			},
+		},
+		{
+			name:   "given a request with a nested field of the wrong type, when we try to validate it, a type mismatch error with the field path should be returned",
+			req:    `{"name": "Night's Watch", "members": [{"id": "32d3e8f1-2f81-49c0-acb6-6dccd84f3dab", "firstName": ["Jon"], "lastName": "Snow"}]}`,
+			target: &createTeamReq{},
+			wantFunc: func(t *testing.T, err error) {
+				var mismatchErr *TypeMismatchError
+				require.True(t, errors.As(err, &mismatchErr), "error should be of type TypeMismatchError")
+				require.Equal(t, "members.0.firstName", mismatchErr.Field)
+				require.Equal(t, "array", mismatchErr.Value)
+			},
+		},
+		{
+			name:   "given a request that is not an object, when we try to validate it, a type mismatch error should be returned",
+			req:    `["Jon", "Snow"]`,
+			target: &api.CreateUserReq{},
+			wantFunc: func(t *testing.T, err error) {
+				var mismatchErr *TypeMismatchError
+				require.True(t, errors.As(err, &mismatchErr), "error should be of type TypeMismatchError")
+				require.Equal(t, "", mismatchErr.Field)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, "", bytes.NewReader([]byte(tt.req)))
+			require.NoError(t, err, "http request creation should not error")
+			httpRequest.Header.Add("Content-Type", "application/json")
+
+			// act
+			require.NotPanics(t, func() {
+				err = reqValidator.ValidateRequest(ctx, httpRequest, tt.target)
+			}, "validator should not panic")
+
+			// assert
+			tt.wantFunc(t, err)
+		})
+	}
+}
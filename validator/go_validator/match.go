@@ -0,0 +1,139 @@
+package govalidator
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/routers"
+)
+
+// PathMatcher returns the path params of the request. The path is the escaped path of the
+// request relative to the matched server, or the whole escaped path if the servers are not
+// checked. It should return an error wrapping routers.ErrPathNotFound if the path doesn't
+// match any operation.
+type PathMatcher func(r *http.Request, path string) (map[string]string, error)
+
+// TemplatePathMatcher returns a PathMatcher of the path templates of the specification,
+// such as "/users/{id}", where every param takes a whole path segment.
+func TemplatePathMatcher(templates ...string) PathMatcher {
+	segments := make([][]string, 0, len(templates))
+	for _, template := range templates {
+		segments = append(segments, strings.Split(strings.Trim(template, "/"), "/"))
+	}
+
+	return func(r *http.Request, path string) (map[string]string, error) {
+		pathSegments := strings.Split(strings.Trim(path, "/"), "/")
+		for _, templateSegments := range segments {
+			if params, ok := matchTemplate(templateSegments, pathSegments); ok {
+				return params, nil
+			}
+		}
+		return nil, fmt.Errorf("%w: no path template matches %q", routers.ErrPathNotFound, path)
+	}
+}
+
+func matchTemplate(template, path []string) (map[string]string, bool) {
+	if len(template) != len(path) {
+		return nil, false
+	}
+
+	params := make(map[string]string)
+	for i, segment := range template {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			value, err := url.PathUnescape(path[i])
+			if err != nil || value == "" {
+				return nil, false
+			}
+			params[segment[1:len(segment)-1]] = value
+			continue
+		}
+		if segment != path[i] {
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+// serverMatcher matches the requests sent to one of the servers of the specification.
+type serverMatcher struct {
+	// absolute is set when the server URL has a scheme and a host, which are matched too.
+	absolute bool
+	re       *regexp.Regexp
+}
+
+var serverVariableRegex = regexp.MustCompile(`\{[^{}]+\}`)
+
+// newServerMatcher compiles the URL template of the server, whose variables match one of
+// their enum values or, if they don't have any, any value within a path segment.
+func newServerMatcher(server *openapi3.Server) serverMatcher {
+	template := strings.TrimSuffix(server.URL, "/")
+
+	var sb strings.Builder
+	last := 0
+	for _, loc := range serverVariableRegex.FindAllStringIndex(template, -1) {
+		sb.WriteString(regexp.QuoteMeta(template[last:loc[0]]))
+		sb.WriteString(serverVariablePattern(server.Variables[template[loc[0]+1:loc[1]-1]]))
+		last = loc[1]
+	}
+	sb.WriteString(regexp.QuoteMeta(template[last:]))
+
+	return serverMatcher{
+		absolute: strings.Contains(template, "://"),
+		// the path of the request must continue after the path of the server at a segment boundary
+		re: regexp.MustCompile("^(?i:" + sb.String() + ")(/.*)?$"),
+	}
+}
+
+func serverVariablePattern(variable *openapi3.ServerVariable) string {
+	if variable == nil || len(variable.Enum) == 0 {
+		return "[^/]*"
+	}
+	values := make([]string, 0, len(variable.Enum))
+	for _, value := range variable.Enum {
+		values = append(values, regexp.QuoteMeta(value))
+	}
+	return "(?:" + strings.Join(values, "|") + ")"
+}
+
+// match returns the escaped path of the request relative to the server.
+func (m serverMatcher) match(r *http.Request) (string, bool) {
+	target := r.URL.EscapedPath()
+	if m.absolute {
+		scheme := r.URL.Scheme
+		if scheme == "" {
+			scheme = "http"
+			if r.TLS != nil {
+				scheme = "https"
+			}
+		}
+		host := r.URL.Host
+		if host == "" {
+			host = r.Host
+		}
+		target = scheme + "://" + host + target
+	}
+
+	matches := m.re.FindStringSubmatch(target)
+	if matches == nil {
+		return "", false
+	}
+	return matches[len(matches)-1], true
+}
+
+// matchServer returns the escaped path of the request relative to the first server of the
+// validator that matches it, or the whole escaped path if the servers are not checked.
+func (v *Validator) matchServer(r *http.Request) (string, error) {
+	if v.options.servers == nil {
+		return r.URL.EscapedPath(), nil
+	}
+	for _, server := range v.options.servers {
+		if path, ok := server.match(r); ok {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("%w: no server matches %q", routers.ErrPathNotFound, r.URL.String())
+}
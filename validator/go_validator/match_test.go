@@ -0,0 +1,112 @@
+package govalidator
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/stretchr/testify/require"
+)
+
+const serversSpec = `
+openapi: 3.0.0
+info:
+  title: Houses
+  version: 1.0.0
+servers:
+  - url: http://api.example.com/v1
+  - url: https://{region}.example.com/{version}
+    variables:
+      region:
+        default: eu
+        enum: [eu, us]
+      version:
+        default: v2
+  - url: /internal
+paths: {}
+`
+
+type houseParams struct {
+	HouseID string `path:"houseId" validate:"required"`
+}
+
+func TestValidateParamsServers(t *testing.T) {
+	ctx := context.Background()
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(serversSpec))
+	require.NoError(t, err)
+	v := NewValidator(WithServers(doc.Servers), WithPathMatcher(TemplatePathMatcher("/houses/{houseId}")))
+
+	tests := []struct {
+		name     string
+		url      string
+		wantID   string
+		wantFunc func(t *testing.T, err error)
+	}{
+		{
+			name:   "given a request to an absolute server, when we try to validate it, the path params should be matched relative to the server",
+			url:    "http://api.example.com/v1/houses/stark",
+			wantID: "stark",
+		},
+		{
+			name:   "given a request to a server with variables, when we try to validate it, any enum value should be accepted",
+			url:    "https://us.example.com/v3/houses/lannister",
+			wantID: "lannister",
+		},
+		{
+			name:   "given a request to a relative server, when we try to validate it, only the path of the server should be matched",
+			url:    "http://localhost:8080/internal/houses/the%20night%27s%20watch",
+			wantID: "the night's watch",
+		},
+		{
+			name: "given a request to a server variable value out of its enum, when we try to validate it, a path not found error should be returned",
+			url:  "https://asia.example.com/v2/houses/stark",
+			wantFunc: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, routers.ErrPathNotFound)
+			},
+		},
+		{
+			name: "given a request whose path only shares a prefix with the server, when we try to validate it, a path not found error should be returned",
+			url:  "http://api.example.com/v10/houses/stark",
+			wantFunc: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, routers.ErrPathNotFound)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			params := &houseParams{}
+
+			// act
+			err := v.ValidateParams(ctx, httptest.NewRequest(http.MethodGet, tt.url, nil), params)
+
+			// assert
+			if tt.wantFunc != nil {
+				tt.wantFunc(t, err)
+				return
+			}
+			require.NoError(t, err, "validator should not error")
+			require.Equal(t, tt.wantID, params.HouseID)
+		})
+	}
+}
+
+func TestValidateRequestServers(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(serversSpec))
+	require.NoError(t, err)
+	v := NewValidator(WithServers(doc.Servers))
+	r := httptest.NewRequest(http.MethodPost, "http://evil.example.org/v1/events", strings.NewReader(`{"name": "Coronation"}`))
+
+	// act
+	err = v.ValidateRequest(ctx, r, &createEventReq{})
+
+	// assert
+	require.ErrorIs(t, err, routers.ErrPathNotFound)
+}
@@ -0,0 +1,103 @@
+package govalidator
+
+import (
+	"github.com/getkin/kin-openapi/openapi3"
+
+	"request_validator/validator/i18n"
+)
+
+// DefaultMaxBodySize is the default limit of the size of the request bodies, in bytes.
+const DefaultMaxBodySize = 1 << 20
+
+// Option configures the validator created by NewValidator.
+type Option func(*options)
+
+type options struct {
+	maxBodySize            int64
+	disallowUnknownFields  bool
+	disallowDuplicateKeys  bool
+	disallowTrailingTokens bool
+	useNumber              bool
+	servers                []serverMatcher
+	pathMatcher            PathMatcher
+	translator             *i18n.Translator
+}
+
+// WithMaxBodySize sets the limit of the size of the request bodies, in bytes. The requests
+// with a bigger body are rejected with ErrBodyTooLarge. A limit of zero or less disables it.
+func WithMaxBodySize(size int64) Option {
+	return func(o *options) {
+		o.maxBodySize = size
+	}
+}
+
+// WithDisallowUnknownFields rejects the request bodies with fields that are not in the
+// struct they are decoded into, like a schema with additionalProperties set to false.
+func WithDisallowUnknownFields() Option {
+	return func(o *options) {
+		o.disallowUnknownFields = true
+	}
+}
+
+// WithDisallowDuplicateKeys rejects the request bodies with an object that has the same key
+// twice, which are otherwise decoded with the last value of the key.
+func WithDisallowDuplicateKeys() Option {
+	return func(o *options) {
+		o.disallowDuplicateKeys = true
+	}
+}
+
+// WithDisallowTrailingTokens rejects the request bodies with more than one JSON document.
+// The data that is not JSON is always rejected.
+func WithDisallowTrailingTokens() Option {
+	return func(o *options) {
+		o.disallowTrailingTokens = true
+	}
+}
+
+// WithUseNumber decodes the numbers of the fields of type interface{} as json.Number
+// instead of float64, so the large integers don't lose precision.
+func WithUseNumber() Option {
+	return func(o *options) {
+		o.useNumber = true
+	}
+}
+
+// WithStrictDecoding rejects the unknown fields, the duplicate keys and the trailing tokens
+// of the request bodies.
+func WithStrictDecoding() Option {
+	return func(o *options) {
+		o.disallowUnknownFields = true
+		o.disallowDuplicateKeys = true
+		o.disallowTrailingTokens = true
+	}
+}
+
+// WithServers checks that the requests are sent to one of the servers of the specification,
+// rejecting the other ones with an error wrapping routers.ErrPathNotFound. The path params
+// are matched against the path of the request relative to its server.
+func WithServers(servers openapi3.Servers) Option {
+	return func(o *options) {
+		o.servers = make([]serverMatcher, 0, len(servers))
+		for _, server := range servers {
+			o.servers = append(o.servers, newServerMatcher(server))
+		}
+	}
+}
+
+// WithPathMatcher sets the matcher of the path params, which is required to bind the fields
+// of the params structs with a path tag.
+func WithPathMatcher(matcher PathMatcher) Option {
+	return func(o *options) {
+		o.pathMatcher = matcher
+	}
+}
+
+// WithTranslator registers the messages of the locales of the translator for the validate
+// tags, so the validation errors can be translated with the Translate method of
+// validator.ValidationErrors.
+func WithTranslator(t *i18n.Translator) Option {
+	return func(o *options) {
+		o.translator = t
+	}
+}
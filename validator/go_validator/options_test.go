@@ -0,0 +1,143 @@
+package govalidator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type createEventReq struct {
+	Name     string                 `json:"name" validate:"required"`
+	Tags     []map[string]string    `json:"tags"`
+	Metadata map[string]interface{} `json:"metadata"`
+}
+
+func TestNewValidatorDecodingOptions(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		opts     []Option
+		req      string
+		wantFunc func(t *testing.T, err error, req *createEventReq)
+	}{
+		{
+			name: "given the default options and a request with an unknown field, when we try to validate it, no error should be returned",
+			req:  `{"name": "Coronation", "place": "Winterfell"}`,
+			wantFunc: func(t *testing.T, err error, req *createEventReq) {
+				require.NoError(t, err, "validator should not error")
+			},
+		},
+		{
+			name: "given the unknown fields are disallowed and a request with an unknown field, when we try to validate it, an unknown field error should be returned",
+			opts: []Option{WithDisallowUnknownFields()},
+			req:  `{"name": "Coronation", "place": "Winterfell"}`,
+			wantFunc: func(t *testing.T, err error, req *createEventReq) {
+				var unknownErr *UnknownFieldError
+				require.True(t, errors.As(err, &unknownErr), "error should be of type UnknownFieldError")
+				require.Equal(t, "place", unknownErr.Field)
+			},
+		},
+		{
+			name: "given the default options and a request with a duplicate key, when we try to validate it, the last value should be decoded",
+			req:  `{"name": "Coronation", "name": "Red Wedding"}`,
+			wantFunc: func(t *testing.T, err error, req *createEventReq) {
+				require.NoError(t, err, "validator should not error")
+				require.Equal(t, "Red Wedding", req.Name)
+			},
+		},
+		{
+			name: "given the duplicate keys are disallowed and a request with a nested duplicate key, when we try to validate it, a duplicate key error with its path should be returned",
+			opts: []Option{WithDisallowDuplicateKeys()},
+			req:  `{"name": "Coronation", "tags": [{"house": "Stark"}, {"house": "Stark", "house": "Bolton"}]}`,
+			wantFunc: func(t *testing.T, err error, req *createEventReq) {
+				var duplicateErr *DuplicateKeyError
+				require.True(t, errors.As(err, &duplicateErr), "error should be of type DuplicateKeyError")
+				require.Equal(t, "tags.1.house", duplicateErr.Field)
+			},
+		},
+		{
+			name: "given the duplicate keys are disallowed and a request with the same key in different objects, when we try to validate it, no error should be returned",
+			opts: []Option{WithDisallowDuplicateKeys()},
+			req:  `{"name": "Coronation", "tags": [{"house": "Stark"}, {"house": "Tully"}]}`,
+			wantFunc: func(t *testing.T, err error, req *createEventReq) {
+				require.NoError(t, err, "validator should not error")
+			},
+		},
+		{
+			name: "given the default options and a request with two JSON documents, when we try to validate it, no error should be returned",
+			req:  `{"name": "Coronation"} {"name": "Red Wedding"}`,
+			wantFunc: func(t *testing.T, err error, req *createEventReq) {
+				require.NoError(t, err, "validator should not error")
+				require.Equal(t, "Coronation", req.Name)
+			},
+		},
+		{
+			name: "given the trailing tokens are disallowed and a request with two JSON documents, when we try to validate it, a trailing data error should be returned",
+			opts: []Option{WithDisallowTrailingTokens()},
+			req:  `{"name": "Coronation"} {"name": "Red Wedding"}`,
+			wantFunc: func(t *testing.T, err error, req *createEventReq) {
+				var trailingErr *TrailingDataError
+				require.True(t, errors.As(err, &trailingErr), "error should be of type TrailingDataError")
+				require.True(t, errors.Is(err, ErrTrailingTokens), "error should wrap ErrTrailingTokens")
+				require.Equal(t, int64(23), trailingErr.Offset)
+			},
+		},
+		{
+			name: "given the default options and a request with a large integer, when we try to validate it, the integer should lose precision",
+			req:  `{"name": "Coronation", "metadata": {"id": 9007199254740993}}`,
+			wantFunc: func(t *testing.T, err error, req *createEventReq) {
+				require.NoError(t, err, "validator should not error")
+				require.Equal(t, float64(9007199254740992), req.Metadata["id"])
+			},
+		},
+		{
+			name: "given the numbers are decoded as json.Number and a request with a large integer, when we try to validate it, the integer should be kept",
+			opts: []Option{WithUseNumber()},
+			req:  `{"name": "Coronation", "metadata": {"id": 9007199254740993}}`,
+			wantFunc: func(t *testing.T, err error, req *createEventReq) {
+				require.NoError(t, err, "validator should not error")
+				require.Equal(t, json.Number("9007199254740993"), req.Metadata["id"])
+			},
+		},
+		{
+			name: "given the strict decoding and a request with a duplicate key, when we try to validate it, a duplicate key error should be returned",
+			opts: []Option{WithStrictDecoding()},
+			req:  `{"name": "Coronation", "name": "Red Wedding"}`,
+			wantFunc: func(t *testing.T, err error, req *createEventReq) {
+				var duplicateErr *DuplicateKeyError
+				require.True(t, errors.As(err, &duplicateErr), "error should be of type DuplicateKeyError")
+			},
+		},
+		{
+			name: "given the strict decoding and a valid request, when we try to validate it, no error should be returned",
+			opts: []Option{WithStrictDecoding(), WithUseNumber()},
+			req:  `{"name": "Coronation", "tags": [{"house": "Stark"}], "metadata": {"guests": 300}}` + "\n",
+			wantFunc: func(t *testing.T, err error, req *createEventReq) {
+				require.NoError(t, err, "validator should not error")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			reqValidator := NewValidator(tt.opts...)
+			httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, "", bytes.NewReader([]byte(tt.req)))
+			require.NoError(t, err, "http request creation should not error")
+			httpRequest.Header.Add("Content-Type", "application/json")
+
+			// act
+			var req createEventReq
+			err = reqValidator.ValidateRequest(ctx, httpRequest, &req)
+
+			// assert
+			tt.wantFunc(t, err, &req)
+		})
+	}
+}
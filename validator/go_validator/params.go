@@ -0,0 +1,278 @@
+package govalidator
+
+import (
+	"context"
+	"encoding"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator"
+)
+
+// The tags of the params struct fields, named after the location of the param in the
+// request, such as `query:"limit"` or `header:"X-Request-Id"`.
+const (
+	ParamInPath   = "path"
+	ParamInQuery  = "query"
+	ParamInHeader = "header"
+	ParamInCookie = "cookie"
+)
+
+var paramLocations = []string{ParamInPath, ParamInQuery, ParamInHeader, ParamInCookie}
+
+// errNoPathMatcher is returned when the params struct has path fields but the validator
+// has no PathMatcher to find their values.
+var errNoPathMatcher = errors.New("the params have path fields but the validator has no path matcher")
+
+// ParamError is a param of the request that can't be converted into the type of its field
+// or that doesn't follow its validate tags.
+type ParamError struct {
+	// In is the location of the param: path, query, header or cookie.
+	In string
+	// Name is the name of the param in the request.
+	Name string
+	// Rule is the failed validate tag, or "type" if the value can't be converted.
+	Rule string
+	// Value is the raw value of the request for the type errors, or the value of the field
+	// for the validate tags.
+	Value interface{}
+	// Err is the conversion error of the type errors.
+	Err error
+	// FieldError is the violation of the validate tag, nil for the type errors.
+	FieldError validator.FieldError
+}
+
+func (e *ParamError) Error() string {
+	if e.Rule == "type" {
+		return fmt.Sprintf("%s param %q: invalid value %q: %v", e.In, e.Name, e.Value, e.Err)
+	}
+	return fmt.Sprintf("%s param %q failed on the %q tag", e.In, e.Name, e.Rule)
+}
+
+func (e *ParamError) Unwrap() error {
+	return e.Err
+}
+
+// ParamErrors collects every ParamError of the request.
+type ParamErrors []*ParamError
+
+func (e ParamErrors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, err := range e {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// paramField is a field of the params struct bound to a param of the request.
+type paramField struct {
+	index int
+	in    string
+	name  string
+}
+
+// paramFields returns the fields of the struct type with a param tag.
+func paramFields(t reflect.Type) []paramField {
+	var fields []paramField
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		for _, in := range paramLocations {
+			if name, ok := field.Tag.Lookup(in); ok && name != "" && name != "-" {
+				fields = append(fields, paramField{index: i, in: in, name: name})
+				break
+			}
+		}
+	}
+	return fields
+}
+
+// ValidateParams binds the path, query, header and cookie params of the request into
+// params, which must be a non-nil pointer to a struct, and validates them with the same
+// validate tags as the request bodies. The fields are bound by their param tag, such as
+// `path:"id"` or `query:"limit"`, and the params missing from the request are left with
+// their zero values. The params that can't be converted into their fields, or that fail
+// their tags, are returned as ParamErrors.
+func (v *Validator) ValidateParams(ctx context.Context, r *http.Request, params interface{}) error {
+	if err := checkTarget(params); err != nil {
+		return err
+	}
+	path, err := v.matchServer(r)
+	if err != nil {
+		return err
+	}
+
+	value := reflect.ValueOf(params).Elem()
+	fields := paramFields(value.Type())
+
+	// --- (1) ----
+	// Find the values of the params in the request and convert them into their fields
+	var pathParams map[string]string
+	for _, field := range fields {
+		if field.in == ParamInPath {
+			if v.options.pathMatcher == nil {
+				return errNoPathMatcher
+			}
+			if pathParams, err = v.options.pathMatcher(r, path); err != nil {
+				return err
+			}
+			break
+		}
+	}
+
+	var paramErrors ParamErrors
+	for _, field := range fields {
+		fieldValue := value.Field(field.index)
+		values := paramValues(r, pathParams, field, isSliceField(fieldValue))
+		if len(values) == 0 {
+			continue
+		}
+		if err := setField(fieldValue, values); err != nil {
+			paramErrors = append(paramErrors, &ParamError{
+				In: field.in, Name: field.name, Rule: "type", Value: strings.Join(values, ","), Err: err,
+			})
+		}
+	}
+	if len(paramErrors) > 0 {
+		return paramErrors
+	}
+
+	// --- (2) ----
+	// Validate the bound struct, reporting the violations by the names of the params
+	err = v.validate.StructCtx(ctx, params)
+	if err == nil {
+		return nil
+	}
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return fmt.Errorf("unable to validate request params: %w", err)
+	}
+	for _, fieldErr := range validationErrors {
+		paramErr := &ParamError{Name: fieldErr.Field(), Rule: fieldErr.Tag(), Value: fieldErr.Value(), FieldError: fieldErr}
+		for _, field := range fields {
+			if value.Type().Field(field.index).Name == topLevelField(fieldErr.StructNamespace()) {
+				paramErr.In, paramErr.Name = field.in, field.name
+				break
+			}
+		}
+		paramErrors = append(paramErrors, paramErr)
+	}
+	return paramErrors
+}
+
+// topLevelField returns the name of the params struct field of a namespace such as
+// "Params.Roles[0]".
+func topLevelField(namespace string) string {
+	if i := strings.IndexByte(namespace, '.'); i >= 0 {
+		namespace = namespace[i+1:]
+	}
+	if i := strings.IndexAny(namespace, ".["); i >= 0 {
+		namespace = namespace[:i]
+	}
+	return namespace
+}
+
+// paramValues returns the raw values of the param. The query params are repeated for every
+// value, while the other ones separate their values with commas, so they are only split when
+// they are bound into a slice: a scalar field takes the whole raw value.
+func paramValues(r *http.Request, pathParams map[string]string, field paramField, split bool) []string {
+	var raw []string
+	switch field.in {
+	case ParamInPath:
+		if value, ok := pathParams[field.name]; ok {
+			raw = []string{value}
+		}
+	case ParamInQuery:
+		return r.URL.Query()[field.name]
+	case ParamInHeader:
+		raw = r.Header.Values(field.name)
+	case ParamInCookie:
+		if cookie, err := r.Cookie(field.name); err == nil {
+			raw = []string{cookie.Value}
+		}
+	}
+	if !split {
+		return raw
+	}
+
+	var values []string
+	for _, value := range raw {
+		for _, item := range strings.Split(value, ",") {
+			values = append(values, strings.TrimSpace(item))
+		}
+	}
+	return values
+}
+
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// isSliceField reports whether the field takes every value of its param.
+func isSliceField(field reflect.Value) bool {
+	return field.Kind() == reflect.Slice && !field.Addr().Type().Implements(textUnmarshalerType)
+}
+
+// setField converts the values into the field: the slices take every value and the other
+// fields only the first one.
+func setField(field reflect.Value, values []string) error {
+	if isSliceField(field) {
+		slice := reflect.MakeSlice(field.Type(), len(values), len(values))
+		for i, value := range values {
+			if err := setValue(slice.Index(i), value); err != nil {
+				return err
+			}
+		}
+		field.Set(slice)
+		return nil
+	}
+	return setValue(field, values[0])
+}
+
+func setValue(field reflect.Value, value string) error {
+	if field.Addr().Type().Implements(textUnmarshalerType) {
+		return field.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(value))
+	}
+
+	switch field.Kind() {
+	case reflect.Ptr:
+		elem := reflect.New(field.Type().Elem())
+		if err := setValue(elem.Elem(), value); err != nil {
+			return err
+		}
+		field.Set(elem)
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(value, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(value, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported param type %s", field.Type())
+	}
+	return nil
+}
@@ -0,0 +1,186 @@
+package govalidator
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/stretchr/testify/require"
+)
+
+type listMembersParams struct {
+	HouseID   string     `path:"houseId" validate:"required,uuid"`
+	Limit     *int       `query:"limit" validate:"omitempty,min=1,max=100"`
+	Roles     []string   `query:"role" validate:"dive,oneof=lord knight maester"`
+	Alive     bool       `query:"alive"`
+	Since     *time.Time `query:"since"`
+	RequestID string     `header:"X-Request-Id" validate:"required"`
+	Languages []string   `header:"Accept-Language"`
+	Session   string     `cookie:"session" validate:"omitempty,alphanum"`
+	Ignored   string
+}
+
+func newListMembersReq(target string) *http.Request {
+	r := httptest.NewRequest(http.MethodGet, target, nil)
+	r.Header.Set("X-Request-Id", "raven-42")
+	return r
+}
+
+func TestValidateParams(t *testing.T) {
+	ctx := context.Background()
+	v := NewValidator(WithPathMatcher(TemplatePathMatcher("/houses", "/houses/{houseId}/members")))
+
+	tests := []struct {
+		name     string
+		req      func() *http.Request
+		wantFunc func(t *testing.T, err error, params *listMembersParams)
+	}{
+		{
+			name: "given a request with valid params, when we try to validate them, every param should be bound into its field",
+			req: func() *http.Request {
+				r := newListMembersReq("/houses/6f1c2a3e-8b4d-4c5e-9f60-7a8b9c0d1e2f/members?limit=10&role=lord&role=knight&alive=true&since=2024-01-02T03:04:05Z")
+				r.Header.Set("Accept-Language", "en, es")
+				r.AddCookie(&http.Cookie{Name: "session", Value: "abc123"})
+				return r
+			},
+			wantFunc: func(t *testing.T, err error, params *listMembersParams) {
+				require.NoError(t, err, "validator should not error")
+				require.Equal(t, "6f1c2a3e-8b4d-4c5e-9f60-7a8b9c0d1e2f", params.HouseID)
+				require.Equal(t, 10, *params.Limit)
+				require.Equal(t, []string{"lord", "knight"}, params.Roles)
+				require.True(t, params.Alive)
+				require.Equal(t, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), *params.Since)
+				require.Equal(t, "raven-42", params.RequestID)
+				require.Equal(t, []string{"en", "es"}, params.Languages)
+				require.Equal(t, "abc123", params.Session)
+			},
+		},
+		{
+			name: "given a request without the optional params, when we try to validate them, they should be left with their zero values",
+			req: func() *http.Request {
+				return newListMembersReq("/houses/6f1c2a3e-8b4d-4c5e-9f60-7a8b9c0d1e2f/members")
+			},
+			wantFunc: func(t *testing.T, err error, params *listMembersParams) {
+				require.NoError(t, err, "validator should not error")
+				require.Nil(t, params.Limit)
+				require.Nil(t, params.Roles)
+				require.Empty(t, params.Session)
+			},
+		},
+		{
+			name: "given a request with params that can't be converted, when we try to validate them, a type error should be returned for each of them",
+			req: func() *http.Request {
+				return newListMembersReq("/houses/6f1c2a3e-8b4d-4c5e-9f60-7a8b9c0d1e2f/members?limit=ten&alive=maybe")
+			},
+			wantFunc: func(t *testing.T, err error, params *listMembersParams) {
+				var paramErrors ParamErrors
+				require.True(t, errors.As(err, &paramErrors), "error should be of type ParamErrors")
+				require.Len(t, paramErrors, 2)
+				require.Equal(t, ParamError{In: ParamInQuery, Name: "limit", Rule: "type", Value: "ten", Err: paramErrors[0].Err}, *paramErrors[0])
+				require.Equal(t, "alive", paramErrors[1].Name)
+				require.Equal(t, "type", paramErrors[1].Rule)
+			},
+		},
+		{
+			name: "given a request with params that fail their tags, when we try to validate them, the violations should be returned by param name",
+			req: func() *http.Request {
+				r := httptest.NewRequest(http.MethodGet, "/houses/stark/members?limit=500&role=king", nil)
+				return r
+			},
+			wantFunc: func(t *testing.T, err error, params *listMembersParams) {
+				var paramErrors ParamErrors
+				require.True(t, errors.As(err, &paramErrors), "error should be of type ParamErrors")
+
+				got := make(map[string]string)
+				for _, paramErr := range paramErrors {
+					require.NotNil(t, paramErr.FieldError)
+					got[paramErr.In+" "+paramErr.Name] = paramErr.Rule
+				}
+				require.Equal(t, map[string]string{
+					"path houseId":        "uuid",
+					"query limit":         "max",
+					"query role":          "oneof",
+					"header X-Request-Id": "required",
+				}, got)
+			},
+		},
+		{
+			name: "given a request with a scalar header that contains commas, when we try to validate it, the whole value should be bound into its field",
+			req: func() *http.Request {
+				r := newListMembersReq("/houses/6f1c2a3e-8b4d-4c5e-9f60-7a8b9c0d1e2f/members")
+				r.Header.Set("X-Request-Id", "Snow, Jon")
+				return r
+			},
+			wantFunc: func(t *testing.T, err error, params *listMembersParams) {
+				require.NoError(t, err, "validator should not error")
+				require.Equal(t, "Snow, Jon", params.RequestID)
+			},
+		},
+		{
+			name: "given a request with a path param that contains commas, when we try to validate it, the whole value should be bound and validated",
+			req: func() *http.Request {
+				return newListMembersReq("/houses/a,b/members")
+			},
+			wantFunc: func(t *testing.T, err error, params *listMembersParams) {
+				require.Equal(t, "a,b", params.HouseID)
+				var paramErrors ParamErrors
+				require.True(t, errors.As(err, &paramErrors), "error should be of type ParamErrors")
+				require.Len(t, paramErrors, 1)
+				require.Equal(t, "houseId", paramErrors[0].Name)
+				require.Equal(t, "uuid", paramErrors[0].Rule)
+				require.Equal(t, "a,b", paramErrors[0].Value)
+			},
+		},
+		{
+			name: "given a request whose path doesn't match any template, when we try to validate it, a path not found error should be returned",
+			req: func() *http.Request {
+				return newListMembersReq("/castles/winterfell")
+			},
+			wantFunc: func(t *testing.T, err error, params *listMembersParams) {
+				require.ErrorIs(t, err, routers.ErrPathNotFound)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			params := &listMembersParams{}
+
+			// act
+			err := v.ValidateParams(ctx, tt.req(), params)
+
+			// assert
+			tt.wantFunc(t, err, params)
+		})
+	}
+}
+
+func TestValidateParamsWithoutPathMatcher(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	v := NewValidator()
+
+	// act
+	err := v.ValidateParams(ctx, newListMembersReq("/houses/stark/members"), &listMembersParams{})
+
+	// assert
+	require.ErrorIs(t, err, errNoPathMatcher)
+}
+
+func TestBindParams(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	v := NewValidator(WithPathMatcher(TemplatePathMatcher("/houses/{houseId}/members")))
+
+	// act
+	params, err := BindParams[listMembersParams](ctx, &v, newListMembersReq("/houses/6f1c2a3e-8b4d-4c5e-9f60-7a8b9c0d1e2f/members?limit=3"))
+
+	// assert
+	require.NoError(t, err, "validator should not error")
+	require.Equal(t, 3, *params.Limit)
+}
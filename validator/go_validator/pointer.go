@@ -0,0 +1,95 @@
+package govalidator
+
+import (
+	"reflect"
+	"strings"
+
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator"
+
+	"request_validator/validator/i18n"
+)
+
+// jsonTagName names the fields after their json tag, so the validation errors report the
+// names of the wire format, falling back to the Go name of the fields without one.
+func jsonTagName(field reflect.StructField) string {
+	name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+	if name == "-" {
+		return ""
+	}
+	return name
+}
+
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// JSONPointer returns the JSON pointer (RFC 6901) of the field of a validation error inside
+// the validated value, such as "/items/3/email" or "/labels/team", the same pointer the
+// kin-openapi validator reports for the field of the request body.
+func JSONPointer(fe validator.FieldError) string {
+	var sb strings.Builder
+	for _, token := range namespaceTokens(fe.Namespace()) {
+		sb.WriteByte('/')
+		sb.WriteString(pointerEscaper.Replace(token))
+	}
+	return sb.String()
+}
+
+// namespaceTokens splits a namespace such as "CreateTeamReq.members[3].labels[team]" into
+// the tokens of its path, dropping the name of the top level struct. The slices and arrays
+// validated with dive have no top level name, their namespace starts with the index.
+func namespaceTokens(namespace string) []string {
+	var tokens []string
+	top := !strings.HasPrefix(namespace, "[")
+	for len(namespace) > 0 {
+		switch namespace[0] {
+		case '.':
+			namespace = namespace[1:]
+		case '[':
+			// the map keys may contain any character, the index ends at the first "]" that is
+			// followed by the next field or index
+			end := indexEnd(namespace)
+			if end < 0 {
+				return append(tokens, namespace[1:])
+			}
+			tokens = append(tokens, namespace[1:end])
+			namespace = namespace[end+1:]
+		default:
+			end := strings.IndexAny(namespace, ".[")
+			if end < 0 {
+				end = len(namespace)
+			}
+			if top {
+				top = false
+			} else {
+				tokens = append(tokens, namespace[:end])
+			}
+			namespace = namespace[end:]
+		}
+	}
+	return tokens
+}
+
+// indexEnd returns the position of the "]" closing the index at the start of the namespace,
+// or -1 if it is not closed.
+func indexEnd(namespace string) int {
+	for i := 1; i < len(namespace); i++ {
+		if namespace[i] != ']' {
+			continue
+		}
+		if i+1 == len(namespace) || namespace[i+1] == '.' || namespace[i+1] == '[' {
+			return i
+		}
+	}
+	return -1
+}
+
+// Translate returns the localized messages of the validation errors by the JSON pointer of
+// their fields. Unlike the Translate method of validator.ValidationErrors, it doesn't need the
+// translations to be registered with WithTranslator.
+func Translate(errs validator.ValidationErrors, trans ut.Translator) map[string]string {
+	messages := make(map[string]string, len(errs))
+	for _, fe := range errs {
+		messages[JSONPointer(fe)] = i18n.TranslateFieldError(trans, fe)
+	}
+	return messages
+}
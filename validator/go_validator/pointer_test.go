@@ -0,0 +1,193 @@
+package govalidator
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/go-playground/validator"
+	"github.com/stretchr/testify/require"
+
+	"request_validator/validator/i18n"
+)
+
+type addressReq struct {
+	City string `json:"city" validate:"required"`
+}
+
+type memberReq struct {
+	Email   string      `json:"email" validate:"required,email"`
+	Address *addressReq `json:"address" validate:"omitempty"`
+}
+
+type createGuildReq struct {
+	Name     string                 `json:"name" validate:"required"`
+	Members  []memberReq            `json:"members" validate:"dive"`
+	Labels   map[string]string      `json:"labels" validate:"dive,keys,min=2,endkeys,max=5"`
+	Branches map[string]addressReq  `json:"branches" validate:"dive"`
+	Nickname string                 `json:",omitempty" validate:"omitempty,min=3"`
+	Secret   string                 `json:"-" validate:"omitempty,min=3"`
+	Extra    map[string]interface{} `json:"extra,omitempty"`
+}
+
+func TestJSONPointer(t *testing.T) {
+	ctx := context.Background()
+	v := NewValidator()
+
+	tests := []struct {
+		name        string
+		req         string
+		wantField   string
+		wantPointer string
+		wantTag     string
+	}{
+		{
+			name:        "given a request without a top level field, when we validate it, the error should report its json name",
+			req:         `{"members": []}`,
+			wantField:   "name",
+			wantPointer: "/name",
+			wantTag:     "required",
+		},
+		{
+			name:        "given a request with an invalid item of a slice, when we validate it, the pointer should have the index of the item",
+			req:         `{"name": "Night's Watch", "members": [{"email": "jon@wall.org"}, {"email": "sam@wall.org"}, {"email": "ghost"}]}`,
+			wantField:   "email",
+			wantPointer: "/members/2/email",
+			wantTag:     "email",
+		},
+		{
+			name:        "given a request with an invalid nested struct, when we validate it, the pointer should follow the json names",
+			req:         `{"name": "Night's Watch", "members": [{"email": "jon@wall.org", "address": {}}]}`,
+			wantField:   "city",
+			wantPointer: "/members/0/address/city",
+			wantTag:     "required",
+		},
+		{
+			name:        "given a request with an invalid map value, when we validate it, the pointer should have the key of the value",
+			req:         `{"name": "Night's Watch", "labels": {"region": "the north"}}`,
+			wantField:   "labels[region]",
+			wantPointer: "/labels/region",
+			wantTag:     "max",
+		},
+		{
+			name:        "given a request with an invalid struct in a map, when we validate it, the pointer should escape the key",
+			req:         `{"name": "Night's Watch", "branches": {"east/watch": {}}}`,
+			wantField:   "city",
+			wantPointer: "/branches/east~1watch/city",
+			wantTag:     "required",
+		},
+		{
+			name:        "given a request with an invalid field without json name, when we validate it, the error should report its Go name",
+			req:         `{"name": "Night's Watch", "Nickname": "NW"}`,
+			wantField:   "Nickname",
+			wantPointer: "/Nickname",
+			wantTag:     "min",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			r, err := http.NewRequest(http.MethodPost, "/guilds", bytes.NewBufferString(tt.req))
+			require.NoError(t, err)
+
+			// act
+			err = v.ValidateRequest(ctx, r, &createGuildReq{})
+
+			// assert
+			var validationErrors validator.ValidationErrors
+			require.True(t, errors.As(err, &validationErrors), "error should be of type validator.ValidationErrors")
+			require.Len(t, validationErrors, 1)
+			require.Equal(t, tt.wantField, validationErrors[0].Field())
+			require.Equal(t, tt.wantPointer, JSONPointer(validationErrors[0]))
+			require.Equal(t, tt.wantTag, validationErrors[0].Tag())
+		})
+	}
+}
+
+func TestNamespaceTokens(t *testing.T) {
+	tests := []struct {
+		name       string
+		namespace  string
+		wantTokens []string
+	}{
+		{
+			name:       "given a top level field, when we split its namespace, the struct name should be dropped",
+			namespace:  "CreateUserReq.id",
+			wantTokens: []string{"id"},
+		},
+		{
+			name:       "given the item of a validated slice, when we split its namespace, it should start with the index",
+			namespace:  "[3].email",
+			wantTokens: []string{"3", "email"},
+		},
+		{
+			name:       "given a map key with brackets and dots, when we split its namespace, the key should be kept whole",
+			namespace:  "Req.labels[a.b[c]].value",
+			wantTokens: []string{"labels", "a.b[c]", "value"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// act
+			tokens := namespaceTokens(tt.namespace)
+
+			// assert
+			require.Equal(t, tt.wantTokens, tokens)
+		})
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	ctx := context.Background()
+	translator := i18n.MustNew()
+	v := NewValidator(WithTranslator(translator))
+
+	r, err := http.NewRequest(http.MethodPost, "/guilds", bytes.NewBufferString(`{"members": [{"email": "ghost"}]}`))
+	require.NoError(t, err)
+	err = v.ValidateRequest(ctx, r, &createGuildReq{})
+	var validationErrors validator.ValidationErrors
+	require.True(t, errors.As(err, &validationErrors), "error should be of type validator.ValidationErrors")
+
+	tests := []struct {
+		name     string
+		locale   string
+		wantMsgs map[string]string
+	}{
+		{
+			name:   "given the English translator, when we translate the errors, the messages should be keyed by JSON pointer",
+			locale: "en-US",
+			wantMsgs: map[string]string{
+				"/name":            "name is required",
+				"/members/0/email": "email must be a valid email",
+			},
+		},
+		{
+			name:   "given the Spanish translator, when we translate the errors, the messages should be in Spanish",
+			locale: "es",
+			wantMsgs: map[string]string{
+				"/name":            "name es obligatorio",
+				"/members/0/email": "email debe ser un email válido",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			trans := translator.Get(tt.locale)
+
+			// act
+			msgs := Translate(validationErrors, trans)
+
+			// assert
+			require.Equal(t, tt.wantMsgs, msgs)
+			for _, fe := range validationErrors {
+				require.Equal(t, tt.wantMsgs[JSONPointer(fe)], fe.Translate(trans), "the translations should be registered in the validator")
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package govalidator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"reflect"
+)
+
+// ValidateResponse validates the response struct, or every struct of a response slice,
+// against its validate tags before it is sent to the client.
+func (v *Validator) ValidateResponse(ctx context.Context, resp interface{}) error {
+	value := reflect.ValueOf(resp)
+	for value.Kind() == reflect.Ptr && !value.IsNil() {
+		value = value.Elem()
+	}
+	if value.Kind() == reflect.Slice || value.Kind() == reflect.Array {
+		return v.validate.VarCtx(ctx, resp, "dive")
+	}
+	return v.validate.StructCtx(ctx, resp)
+}
+
+// ResponseReporter is notified of the responses that failed the validation in report only mode.
+type ResponseReporter func(ctx context.Context, resp interface{}, err error)
+
+// LogResponseReporter writes the response validation errors to the standard logger.
+func LogResponseReporter(ctx context.Context, resp interface{}, err error) {
+	log.Printf("invalid %T response: %v", resp, err)
+}
+
+// ResponseOption configures the behaviour of the ResponseWriter.
+type ResponseOption func(*ResponseWriter)
+
+// WithReportOnly makes the ResponseWriter encode the responses that failed the validation,
+// passing the validation error to the reporter, which defaults to LogResponseReporter if it
+// is nil. It is meant to roll out the response validation in production without breaking
+// the clients.
+func WithReportOnly(reporter ResponseReporter) ResponseOption {
+	return func(rw *ResponseWriter) {
+		if reporter == nil {
+			reporter = LogResponseReporter
+		}
+		rw.reporter = reporter
+	}
+}
+
+// ResponseWriter validates the response structs before encoding them as JSON.
+type ResponseWriter struct {
+	validator *Validator
+	reporter  ResponseReporter
+}
+
+// NewResponseWriter creates a ResponseWriter that validates the responses with v. By
+// default the responses that fail the validation are not written.
+func NewResponseWriter(v *Validator, opts ...ResponseOption) *ResponseWriter {
+	rw := &ResponseWriter{validator: v}
+	for _, opt := range opts {
+		opt(rw)
+	}
+	return rw
+}
+
+// WriteJSON validates the response and writes it as the JSON body of a response with the
+// given status code. If the validation fails, nothing is written and the validation error
+// is returned so the handler can respond with an error, unless the writer is in report only
+// mode.
+func (rw *ResponseWriter) WriteJSON(ctx context.Context, w http.ResponseWriter, status int, resp interface{}) error {
+	if err := rw.validator.ValidateResponse(ctx, resp); err != nil {
+		if rw.reporter == nil {
+			return fmt.Errorf("invalid response: %w", err)
+		}
+		rw.reporter(ctx, resp, err)
+	}
+
+	body, err := json.Marshal(resp)
+	if err != nil {
+		return fmt.Errorf("unable to marshal response body: %w", err)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, err = w.Write(append(body, '\n'))
+	return err
+}
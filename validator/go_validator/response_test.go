@@ -0,0 +1,134 @@
+package govalidator
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-playground/validator"
+	"github.com/stretchr/testify/require"
+
+	api "request_validator/http/v2"
+)
+
+func TestValidateResponse(t *testing.T) {
+	// create the validator
+	ctx := context.Background()
+	respValidator := NewValidator()
+
+	tests := []struct {
+		name     string
+		resp     interface{}
+		wantFunc func(t *testing.T, err error)
+	}{
+		{
+			name:     "given a complete response, when we try to validate it, no error should be returned",
+			resp:     &api.CreateUserReq{Id: "32d3e8f1-2f81-49c0-acb6-6dccd84f3dab", FirstName: "Jon", LastName: "Snow"},
+			wantFunc: func(t *testing.T, err error) { require.NoError(t, err, "validator should not error") },
+		},
+		{
+			name: "given a response that does not have a required field set, when we try to validate it, an error should be returned",
+			resp: api.CreateUserReq{Id: "32d3e8f1-2f81-49c0-acb6-6dccd84f3dab", FirstName: "Jon"},
+			wantFunc: func(t *testing.T, err error) {
+				var validationErrors validator.ValidationErrors
+				require.True(t, errors.As(err, &validationErrors), "error should be of type validator.ValidationErrors")
+				require.Equal(t, "lastName", validationErrors[0].Field())
+				require.Equal(t, "/lastName", JSONPointer(validationErrors[0]))
+			},
+		},
+		{
+			name: "given a list response with an incomplete item, when we try to validate it, an error should be returned",
+			resp: []api.CreateUserReq{
+				{Id: "32d3e8f1-2f81-49c0-acb6-6dccd84f3dab", FirstName: "Jon", LastName: "Snow"},
+				{Id: "sadwefsds", FirstName: "Arya", LastName: "Stark"},
+			},
+			wantFunc: func(t *testing.T, err error) {
+				var validationErrors validator.ValidationErrors
+				require.True(t, errors.As(err, &validationErrors), "error should be of type validator.ValidationErrors")
+				require.Equal(t, "id", validationErrors[0].Field())
+				require.Equal(t, "/1/id", JSONPointer(validationErrors[0]))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// act
+			err := respValidator.ValidateResponse(ctx, tt.resp)
+
+			// assert
+			tt.wantFunc(t, err)
+		})
+	}
+}
+
+func TestResponseWriter(t *testing.T) {
+	// create the validator
+	ctx := context.Background()
+	respValidator := NewValidator()
+	incomplete := &api.CreateUserReq{Id: "32d3e8f1-2f81-49c0-acb6-6dccd84f3dab", FirstName: "Jon"}
+
+	tests := []struct {
+		name       string
+		resp       interface{}
+		reportOnly bool
+		wantErr    bool
+		wantReport bool
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "given a complete response, when we write it, it should be encoded",
+			resp:       &api.CreateUserReq{Id: "32d3e8f1-2f81-49c0-acb6-6dccd84f3dab", FirstName: "Jon", LastName: "Snow"},
+			wantStatus: http.StatusCreated,
+			wantBody:   `{"firstName":"Jon","id":"32d3e8f1-2f81-49c0-acb6-6dccd84f3dab","lastName":"Snow"}`,
+		},
+		{
+			name:       "given an incomplete response, when we write it, an error should be returned and nothing should be written",
+			resp:       incomplete,
+			wantErr:    true,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "given an incomplete response and the report only mode, when we write it, it should be reported and encoded",
+			resp:       incomplete,
+			reportOnly: true,
+			wantReport: true,
+			wantStatus: http.StatusCreated,
+			wantBody:   `{"firstName":"Jon","id":"32d3e8f1-2f81-49c0-acb6-6dccd84f3dab","lastName":""}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			var reported error
+			var opts []ResponseOption
+			if tt.reportOnly {
+				opts = append(opts, WithReportOnly(func(ctx context.Context, resp interface{}, err error) {
+					reported = err
+				}))
+			}
+			writer := NewResponseWriter(&respValidator, opts...)
+			recorder := httptest.NewRecorder()
+
+			// act
+			err := writer.WriteJSON(ctx, recorder, http.StatusCreated, tt.resp)
+
+			// assert
+			if tt.wantErr {
+				var validationErrors validator.ValidationErrors
+				require.True(t, errors.As(err, &validationErrors), "error should be of type validator.ValidationErrors")
+				require.Empty(t, recorder.Body.String(), "nothing should be written")
+			} else {
+				require.NoError(t, err, "writer should not error")
+				require.JSONEq(t, tt.wantBody, recorder.Body.String())
+				require.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+			}
+			require.Equal(t, tt.wantStatus, recorder.Code)
+			require.Equal(t, tt.wantReport, reported != nil)
+		})
+	}
+}
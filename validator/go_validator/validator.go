@@ -1,39 +1,166 @@
 package govalidator
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"reflect"
+	"regexp"
+	"sync"
 
 	"github.com/go-playground/validator"
+
+	"request_validator/validator/formats"
+	"request_validator/validator/i18n"
 )
 
 type Validator struct {
 	validate *validator.Validate
+	options  options
 }
 
-func NewValidator() Validator {
-	ret := Validator{validate: validator.New()}
+// NewValidator creates a validator of the request bodies against the validate tags of the
+// structs they are decoded into.
+func NewValidator(opts ...Option) Validator {
+	o := &options{maxBodySize: DefaultMaxBodySize}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	ret := Validator{validate: validator.New(), options: *o}
+	ret.validate.RegisterTagNameFunc(jsonTagName)
+	registerFormats(ret.validate)
+	registerPattern(ret.validate)
+	if o.translator != nil {
+		if err := i18n.RegisterTranslations(ret.validate, o.translator); err != nil {
+			panic(fmt.Sprintf("unable to register the translations: %v", err))
+		}
+	}
 	return ret
 }
 
+// registerFormats sets the tags of the formats library, replacing the go-playground ones
+// with the same name, so a tag validates a string the same way as the OpenAPI format.
+func registerFormats(validate *validator.Validate) {
+	for name, format := range formats.All() {
+		format := format
+		err := validate.RegisterValidation(name, func(fl validator.FieldLevel) bool {
+			field := fl.Field()
+			return field.Kind() == reflect.String && format.Validate(field.String()) == nil
+		})
+		if err != nil {
+			panic(fmt.Sprintf("unable to register the %q format: %v", name, err))
+		}
+	}
+}
+
+// patterns caches the compiled regular expressions of the pattern tags.
+var patterns sync.Map
+
+// registerPattern sets the pattern tag, which checks that a string matches the regular
+// expression of its param like the pattern of an OpenAPI schema, such as
+// `validate:"pattern=^[a-z]+$"`. The commas and pipes of the expression are written as 0x2C
+// and 0x7C, and an invalid expression fails the validation.
+func registerPattern(validate *validator.Validate) {
+	err := validate.RegisterValidation("pattern", func(fl validator.FieldLevel) bool {
+		field := fl.Field()
+		if field.Kind() != reflect.String {
+			return false
+		}
+		re, ok := patterns.Load(fl.Param())
+		if !ok {
+			compiled, err := regexp.Compile(fl.Param())
+			if err != nil {
+				return false
+			}
+			re, _ = patterns.LoadOrStore(fl.Param(), compiled)
+		}
+		return re.(*regexp.Regexp).MatchString(field.String())
+	})
+	if err != nil {
+		panic(fmt.Sprintf("unable to register the pattern tag: %v", err))
+	}
+}
+
+// ValidateRequest decodes the request body into req, which must be a non-nil pointer to a
+// struct, and validates it. The errors of a body that is empty, is not valid JSON or doesn't
+// fit the struct are returned before the validate tags are checked, and the violations of
+// the tags are returned as validator.ValidationErrors, whose fields are named after their json
+// tags and can be located in the body with JSONPointer. The requests sent to a server that is
+// not one of WithServers are rejected before the body is read.
 func (v *Validator) ValidateRequest(ctx context.Context, r *http.Request, req interface{}) error {
+	if err := checkTarget(req); err != nil {
+		return err
+	}
+	if _, err := v.matchServer(r); err != nil {
+		return err
+	}
 
 	// --- (1) ----
-	// Try to decode the request body into the struct.
-	err := json.NewDecoder(r.Body).Decode(&req)
+	// Buffer the request body, restoring it for the next handlers, and try to decode it
+	// into the struct.
+	body, err := v.readBody(r)
 	if err != nil {
-		return fmt.Errorf("unable to unmarshal request body: %w", err)
+		return err
+	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		return ErrEmptyBody
+	}
+	if err := v.decode(body, req); err != nil {
+		return err
 	}
 
 	// --- (2) ----
 	// Validate the unmarshalled struct
 	err = v.validate.StructCtx(ctx, req)
 	if err != nil {
-		validationErrors := err.(validator.ValidationErrors)
-		return validationErrors
+		var validationErrors validator.ValidationErrors
+		if errors.As(err, &validationErrors) {
+			return validationErrors
+		}
+		return fmt.Errorf("unable to validate request body: %w", err)
 	}
 
 	return nil
 }
+
+// decode decodes the JSON document of the body into req, checking that it is only followed
+// by white spaces or, unless they are disallowed, other JSON documents.
+func (v *Validator) decode(body []byte, req interface{}) error {
+	if v.options.disallowDuplicateKeys {
+		if err := checkDuplicateKeys(body); err != nil {
+			return err
+		}
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	if v.options.disallowUnknownFields {
+		decoder.DisallowUnknownFields()
+	}
+	if v.options.useNumber {
+		decoder.UseNumber()
+	}
+	if err := decoder.Decode(req); err != nil {
+		return decodeError(err)
+	}
+
+	for {
+		// skip the white spaces so the offset points to the trailing data
+		offset := decoder.InputOffset()
+		offset += int64(len(body[offset:]) - len(bytes.TrimLeft(body[offset:], " \t\r\n")))
+		var next json.RawMessage
+		err := decoder.Decode(&next)
+		switch {
+		case err == io.EOF:
+			return nil
+		case err != nil:
+			return &TrailingDataError{Offset: offset, Err: err}
+		case v.options.disallowTrailingTokens:
+			return &TrailingDataError{Offset: offset, Err: ErrTrailingTokens}
+		}
+	}
+}
@@ -124,6 +124,119 @@ func TestValidator(t *testing.T) {
 	}
 }
 
+func TestFormatTags(t *testing.T) {
+	// create the validator
+	ctx := context.Background()
+	reqValidator := NewValidator()
+
+	type createOrderReq struct {
+		Country   string  `json:"country" validate:"required,iso3166-alpha2"`
+		Currency  string  `json:"currency" validate:"required,iso4217"`
+		CreatedAt string  `json:"createdAt" validate:"required,date-time"`
+		Phone     *string `json:"phone,omitempty" validate:"omitempty,e164"`
+		Email     *string `json:"email,omitempty" validate:"omitempty,email"`
+	}
+
+	tests := []struct {
+		name     string
+		req      string
+		wantFunc func(t *testing.T, err error)
+	}{
+		{
+			name:     "given a request whose fields follow their formats, when we try to validate it, no error should be returned",
+			req:      `{"country": "ES", "currency": "EUR", "createdAt": "2017-07-21T17:32:28Z", "phone": "+34600000000", "email": "Jon.Snow@Winterfell.COM"}`,
+			wantFunc: func(t *testing.T, err error) { require.NoError(t, err, "validator should not error") },
+		},
+		{
+			name: "given a request whose fields don't follow their formats, when we try to validate it, an error for each field should be returned",
+			req:  `{"country": "UK", "currency": "EUR", "createdAt": "2017-07-21", "phone": "600000000"}`,
+			wantFunc: func(t *testing.T, err error) {
+				var validationErrors validator.ValidationErrors
+				require.True(t, errors.As(err, &validationErrors), "error should be of type validator.ValidationErrors")
+				var tags []string
+				for _, fe := range validationErrors {
+					tags = append(tags, fe.Tag())
+				}
+				require.Equal(t, []string{"iso3166-alpha2", "date-time", "e164"}, tags)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, "", bytes.NewReader([]byte(tt.req)))
+			require.NoError(t, err, "http request creation should not error")
+			httpRequest.Header.Add("Content-Type", "application/json")
+
+			// act
+			var req createOrderReq
+			err = reqValidator.ValidateRequest(ctx, httpRequest, &req)
+
+			// assert
+			tt.wantFunc(t, err)
+		})
+	}
+}
+
+func TestPatternTag(t *testing.T) {
+	// create the validator
+	ctx := context.Background()
+	reqValidator := NewValidator()
+
+	type createRavenReq struct {
+		Code   string `json:"code" validate:"required,pattern=^[A-Z]{2}-[0-9]{3}$"`
+		Route  string `json:"route" validate:"omitempty,pattern=^(wall0x7Cking's landing)(0x2C[a-z ]+){00x2C2}$"`
+		Broken string `json:"broken" validate:"omitempty,pattern=["`
+	}
+
+	tests := []struct {
+		name     string
+		req      string
+		wantTags []string
+	}{
+		{
+			name: "given a request whose fields match their patterns, when we try to validate it, no error should be returned",
+			req:  `{"code": "NW-042", "route": "wall,winterfell"}`,
+		},
+		{
+			name:     "given a request whose fields don't match their patterns, when we try to validate it, an error for each field should be returned",
+			req:      `{"code": "nw-42", "route": "dragonstone"}`,
+			wantTags: []string{"pattern", "pattern"},
+		},
+		{
+			name:     "given a field with an invalid pattern, when we try to validate it, the validation should fail",
+			req:      `{"code": "NW-042", "broken": "anything"}`,
+			wantTags: []string{"pattern"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, "", bytes.NewReader([]byte(tt.req)))
+			require.NoError(t, err, "http request creation should not error")
+
+			// act
+			var req createRavenReq
+			err = reqValidator.ValidateRequest(ctx, httpRequest, &req)
+
+			// assert
+			if tt.wantTags == nil {
+				require.NoError(t, err, "validator should not error")
+				return
+			}
+			var validationErrors validator.ValidationErrors
+			require.True(t, errors.As(err, &validationErrors), "error should be of type validator.ValidationErrors")
+			var tags []string
+			for _, fe := range validationErrors {
+				tags = append(tags, fe.Tag())
+			}
+			require.Equal(t, tt.wantTags, tags)
+		})
+	}
+}
+
 func BenchmarkValidator(b *testing.B) {
 	b.Run("Go validator benchmark with correct request", func(b *testing.B) {
 		// arrange
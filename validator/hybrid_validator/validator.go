@@ -0,0 +1,69 @@
+package hybridvalidator
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/getkin/kin-openapi/openapi3"
+
+	govalidator "request_validator/validator/go_validator"
+	kinvalidator "request_validator/validator/kin_validator"
+)
+
+// Validator uses the kin-openapi validator to validate the origin server, path, query and
+// header params and the security of the request and the go-playground validator to validate
+// its body against the generated Go structs.
+type Validator struct {
+	params *kinvalidator.Validator
+	body   govalidator.Validator
+}
+
+// MustCreateValidator creates a validator with the given options and panics if the document
+// is not valid.
+func MustCreateValidator(ctx context.Context, doc *openapi3.T, opts ...kinvalidator.Option) *Validator {
+	v, err := NewValidator(ctx, doc, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// NewValidator creates a validator of the requests against the given document. The options
+// configure the kin-openapi validator of everything but the body, so the formats, the
+// authenticators and the router are the ones of the kin validator.
+func NewValidator(ctx context.Context, doc *openapi3.T, opts ...kinvalidator.Option) (*Validator, error) {
+	opts = append(opts[:len(opts):len(opts)], kinvalidator.WithExcludeRequestBody())
+	params, err := kinvalidator.NewValidator(ctx, doc, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Validator{
+		params: params,
+		body:   govalidator.NewValidator(),
+	}, nil
+}
+
+func (v *Validator) ValidateRequest(ctx context.Context, httpRq *http.Request, req interface{}) error {
+
+	// --- (1) ----
+	// Find the route of the request and validate its server, params and security, leaving
+	// the body to the go validator.
+	route, err := v.params.ValidateRoute(ctx, httpRq)
+	if err != nil {
+		return err
+	}
+
+	// --- (2) ----
+	// Decode and validate the body against the generated struct, if the operation has one.
+	requestBody := route.Operation.RequestBody
+	if requestBody == nil || requestBody.Value == nil {
+		return nil
+	}
+	err = v.body.ValidateRequest(ctx, httpRq, req)
+	if errors.Is(err, govalidator.ErrEmptyBody) && !requestBody.Value.Required {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,323 @@
+package hybridvalidator
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/go-playground/validator"
+	"github.com/stretchr/testify/require"
+
+	api "request_validator/http/v2"
+	govalidator "request_validator/validator/go_validator"
+)
+
+const correctRequest = `
+{
+	"id": "32d3e8f1-2f81-49c0-acb6-6dccd84f3dab",
+	"firstName": "Jon",
+	"lastName": "Snow"
+}`
+
+const missingMandatoryFieldRequest = `
+{
+	"firstName": "Jon",
+	"lastName": "Snow"
+}
+`
+
+const invalidFormatFieldRequest = `
+{
+	"id": "sadwefsds",
+	"firstName": "Jon",
+	"lastName": "Snow"
+}
+`
+
+func TestValidator(t *testing.T) {
+	// create the validator
+	ctx := context.Background()
+	swaggerDoc, err := api.GetSwagger()
+	require.NoError(t, err, "swagger recovery should not error")
+	reqValidator := MustCreateValidator(ctx, swaggerDoc)
+
+	tests := []struct {
+		name     string
+		req      string
+		url      string
+		wantFunc func(t *testing.T, err error, req *api.CreateUserReq)
+	}{
+		{
+			name: "given a request that whose ID is not of a UUID type, when we try to validate it, an error should be returned",
+			req:  invalidFormatFieldRequest,
+			url:  "http://api.example.com/v1/users/create",
+			wantFunc: func(t *testing.T, err error, req *api.CreateUserReq) {
+				require.Error(t, err, "validator should error")
+				var validationErrors validator.ValidationErrors
+				require.True(t, errors.As(err, &validationErrors), "error should be of type validator.ValidationErrors")
+			},
+		},
+		{
+			name: "given a valid request that does not come from one of the specified url server, when we try to validate it, an error should be returned",
+			req:  correctRequest,
+			url:  "XXXXXXXXXXX",
+			wantFunc: func(t *testing.T, err error, req *api.CreateUserReq) {
+				require.Error(t, err, "validator should error")
+				require.True(t, errors.Is(err, routers.ErrPathNotFound), "error should be of type ErrPathNotFound")
+			},
+		},
+		{
+			name: "given a request that does not have a required field specified, when we try to validate it, an error should be returned",
+			req:  missingMandatoryFieldRequest,
+			url:  "http://api.example.com/v1/users/create",
+			wantFunc: func(t *testing.T, err error, req *api.CreateUserReq) {
+				require.Error(t, err, "validator should error")
+				var validationErrors validator.ValidationErrors
+				require.True(t, errors.As(err, &validationErrors), "error should be of type validator.ValidationErrors")
+			},
+		},
+		{
+			name: "given a valid request from a registered server url, when we try to validate it, no error should be returned",
+			req:  correctRequest,
+			url:  "http://api.example.com/v1/users/create",
+			wantFunc: func(t *testing.T, err error, req *api.CreateUserReq) {
+				require.NoError(t, err, "validator should not error")
+				require.Equal(t, req.Id, "32d3e8f1-2f81-49c0-acb6-6dccd84f3dab")
+				require.Equal(t, req.FirstName, "Jon")
+				require.Equal(t, req.LastName, "Snow")
+			},
+		},
+		{
+			name: "given a valid request from a registered server url but with an invalid email formatted field, when we try to validate it, an error should be returned",
+			req: `
+			{
+				"id": "32d3e8f1-2f81-49c0-acb6-6dccd84f3dab",
+				"firstName": "Jon",
+				"lastName": "Snow",
+				"email": "this_is_a_test"
+			}`,
+			url: "http://api.example.com/v1/users/create",
+			wantFunc: func(t *testing.T, err error, req *api.CreateUserReq) {
+				require.Error(t, err, "validator should error")
+				var validationErrors validator.ValidationErrors
+				require.True(t, errors.As(err, &validationErrors), "error should be of type validator.ValidationErrors")
+			},
+		},
+		{
+			name: "given a valid request from a registered server url with a valid email format, when we try to validate it, no error should be returned",
+			req: `
+			{
+				"id": "32d3e8f1-2f81-49c0-acb6-6dccd84f3dab",
+				"firstName": "Jon",
+				"lastName": "Snow",
+				"email": "jon_snow@winterfell.com"
+			}`,
+			url: "http://api.example.com/v1/users/create",
+			wantFunc: func(t *testing.T, err error, req *api.CreateUserReq) {
+				require.NoError(t, err, "validator should not error")
+				require.Equal(t, *req.Email, "jon_snow@winterfell.com")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, tt.url, bytes.NewReader([]byte(tt.req)))
+			httpRequest.Header.Add("Content-Type", "application/json")
+			require.NoError(t, err, "http request creation should not error")
+
+			// act
+			var req api.CreateUserReq
+			err = reqValidator.ValidateRequest(ctx, httpRequest, &req)
+
+			// assert
+			tt.wantFunc(t, err, &req)
+		})
+	}
+}
+
+const usersSpec = `
+openapi: 3.0.0
+info:
+  title: Users
+  version: 1.0.0
+servers:
+  - url: http://api.example.com/v1
+paths:
+  /users/{id}:
+    get:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+            format: uuid
+      responses:
+        '200':
+          description: ok
+  /users/create:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+      responses:
+        '200':
+          description: ok
+  /users/update:
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+      responses:
+        '200':
+          description: ok
+`
+
+func TestValidatorRequestBodies(t *testing.T) {
+	// create the validator
+	ctx := context.Background()
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(usersSpec))
+	require.NoError(t, err, "spec loading should not error")
+	reqValidator := MustCreateValidator(ctx, doc)
+
+	tests := []struct {
+		name     string
+		method   string
+		url      string
+		req      string
+		wantFunc func(t *testing.T, err error)
+	}{
+		{
+			name:   "given a request to an operation without a request body, when we try to validate it, no error should be returned",
+			method: http.MethodGet,
+			url:    "http://api.example.com/v1/users/32d3e8f1-2f81-49c0-acb6-6dccd84f3dab",
+			wantFunc: func(t *testing.T, err error) {
+				require.NoError(t, err, "validator should not error")
+			},
+		},
+		{
+			name:   "given a path param that is not of its format, when we try to validate it, an error should be returned",
+			method: http.MethodGet,
+			url:    "http://api.example.com/v1/users/not-a-uuid",
+			wantFunc: func(t *testing.T, err error) {
+				require.Error(t, err, "validator should error")
+				var requestErr *openapi3filter.RequestError
+				require.True(t, errors.As(err, &requestErr), "error should be of type RequestError")
+			},
+		},
+		{
+			name:   "given an empty body for an optional request body, when we try to validate it, no error should be returned",
+			method: http.MethodPost,
+			url:    "http://api.example.com/v1/users/create",
+			wantFunc: func(t *testing.T, err error) {
+				require.NoError(t, err, "validator should not error")
+			},
+		},
+		{
+			name:   "given an empty body for a required request body, when we try to validate it, an empty body error should be returned",
+			method: http.MethodPost,
+			url:    "http://api.example.com/v1/users/update",
+			wantFunc: func(t *testing.T, err error) {
+				require.True(t, errors.Is(err, govalidator.ErrEmptyBody), "error should be ErrEmptyBody")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			httpRequest, err := http.NewRequestWithContext(ctx, tt.method, tt.url, bytes.NewReader([]byte(tt.req)))
+			require.NoError(t, err, "http request creation should not error")
+			httpRequest.Header.Add("Content-Type", "application/json")
+
+			// act
+			var req api.CreateUserReq
+			err = reqValidator.ValidateRequest(ctx, httpRequest, &req)
+
+			// assert
+			tt.wantFunc(t, err)
+		})
+	}
+}
+
+func TestNewValidatorInvalidDocument(t *testing.T) {
+	// arrange
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(strings.Replace(usersSpec, "version: 1.0.0", "version: ''", 1)))
+	require.NoError(t, err, "spec loading should not error")
+
+	// act
+	v, err := NewValidator(context.Background(), doc)
+
+	// assert
+	require.Error(t, err, "validator creation should error")
+	require.Nil(t, v)
+}
+
+func BenchmarkValidator(b *testing.B) {
+	b.Run("Hybrid validator benchmark with correct request", func(b *testing.B) {
+		// arrange
+		ctx := context.Background()
+		var req api.CreateUserReq
+
+		// create the validator
+		swaggerDoc, err := api.GetSwagger()
+		require.NoError(b, err, "swagger recovery should not error")
+		reqValidator := MustCreateValidator(ctx, swaggerDoc)
+
+		for i := 0; i < b.N; i++ {
+			httpRequest, _ := http.NewRequestWithContext(ctx, http.MethodPost, "http://api.example.com/v1/users/create", bytes.NewReader([]byte(correctRequest)))
+			httpRequest.Header.Add("Content-Type", "application/json")
+
+			reqValidator.ValidateRequest(ctx, httpRequest, &req)
+		}
+	})
+
+	b.Run("Hybrid validator benchmark with invalid format request", func(b *testing.B) {
+		// arrange
+		ctx := context.Background()
+		var req api.CreateUserReq
+
+		// create the validator
+		swaggerDoc, err := api.GetSwagger()
+		require.NoError(b, err, "swagger recovery should not error")
+		reqValidator := MustCreateValidator(ctx, swaggerDoc)
+
+		for i := 0; i < b.N; i++ {
+			httpRequest, _ := http.NewRequestWithContext(ctx, http.MethodPost, "http://api.example.com/v1/users/create", bytes.NewReader([]byte(invalidFormatFieldRequest)))
+			httpRequest.Header.Add("Content-Type", "application/json")
+
+			reqValidator.ValidateRequest(ctx, httpRequest, &req)
+		}
+	})
+
+	b.Run("Hybrid validator benchmark with missing field request", func(b *testing.B) {
+		// arrange
+		ctx := context.Background()
+		var req api.CreateUserReq
+
+		// create the validator
+		swaggerDoc, err := api.GetSwagger()
+		require.NoError(b, err, "swagger recovery should not error")
+		reqValidator := MustCreateValidator(ctx, swaggerDoc)
+
+		for i := 0; i < b.N; i++ {
+			httpRequest, _ := http.NewRequestWithContext(ctx, http.MethodPost, "http://api.example.com/v1/users/create", bytes.NewReader([]byte(missingMandatoryFieldRequest)))
+			httpRequest.Header.Add("Content-Type", "application/json")
+
+			reqValidator.ValidateRequest(ctx, httpRequest, &req)
+		}
+	})
+}
@@ -0,0 +1,144 @@
+// Package i18n provides the localized messages of the validation errors of every validator
+// implementation, picking the language of each request from its Accept-Language header.
+package i18n
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/locales"
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/es"
+	ut "github.com/go-playground/universal-translator"
+)
+
+// The locales with messages. English is the fallback of the other languages.
+const (
+	English = "en"
+	Spanish = "es"
+)
+
+// Translator holds the translators of the supported locales.
+type Translator struct {
+	uni     *ut.UniversalTranslator
+	locales []string
+}
+
+// New creates a Translator of the supported locales, loading their messages.
+func New() (*Translator, error) {
+	supported := []locales.Translator{en.New(), es.New()}
+	t := &Translator{uni: ut.New(supported[0], supported...)}
+
+	for _, locale := range supported {
+		name := locale.Locale()
+		messages, ok := catalog[name]
+		if !ok {
+			return nil, fmt.Errorf("missing messages of the %q locale", name)
+		}
+		trans, _ := t.uni.GetTranslator(name)
+		for key, text := range messages {
+			if err := trans.Add(key, text, false); err != nil {
+				return nil, fmt.Errorf("unable to add the %q message of the %q locale: %w", key, name, err)
+			}
+		}
+		t.locales = append(t.locales, name)
+	}
+	return t, nil
+}
+
+// MustNew creates a Translator and panics if the messages can't be loaded.
+func MustNew() *Translator {
+	t, err := New()
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// Translators returns the translators of every supported locale.
+func (t *Translator) Translators() []ut.Translator {
+	translators := make([]ut.Translator, 0, len(t.locales))
+	for _, name := range t.locales {
+		trans, _ := t.uni.GetTranslator(name)
+		translators = append(translators, trans)
+	}
+	return translators
+}
+
+// Fallback returns the translator used when none of the languages of a request is supported.
+func (t *Translator) Fallback() ut.Translator {
+	return t.uni.GetFallback()
+}
+
+// Get returns the translator of the preferred supported language of an Accept-Language
+// header, such as "es-ES,es;q=0.9,en;q=0.8", or the fallback one.
+func (t *Translator) Get(acceptLanguage string) ut.Translator {
+	for _, tag := range ParseAcceptLanguage(acceptLanguage) {
+		// try the region specific locale before the language one, "es_es" then "es"
+		locale := strings.ReplaceAll(tag, "-", "_")
+		if trans, found := t.uni.GetTranslator(locale); found {
+			return trans
+		}
+		if i := strings.IndexByte(locale, '_'); i > 0 {
+			if trans, found := t.uni.GetTranslator(locale[:i]); found {
+				return trans
+			}
+		}
+	}
+	return t.Fallback()
+}
+
+// FromRequest returns the translator of the language preferred by the request.
+func (t *Translator) FromRequest(r *http.Request) ut.Translator {
+	if r == nil {
+		return t.Fallback()
+	}
+	return t.Get(r.Header.Get("Accept-Language"))
+}
+
+// ParseAcceptLanguage returns the language tags of an Accept-Language header ordered by their
+// quality, keeping the order of the header between the tags of the same quality. The wildcard
+// and the tags with a zero or malformed quality are dropped.
+func ParseAcceptLanguage(header string) []string {
+	type language struct {
+		tag     string
+		quality float64
+	}
+
+	var languages []language
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(part, ";")
+		tag := strings.TrimSpace(fields[0])
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		quality := 1.0
+		for _, param := range fields[1:] {
+			name, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+			if strings.TrimSpace(name) != "q" {
+				continue
+			}
+			q, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil || q < 0 || q > 1 {
+				q = 0
+			}
+			quality = q
+		}
+		if quality > 0 {
+			languages = append(languages, language{tag: tag, quality: quality})
+		}
+	}
+
+	sort.SliceStable(languages, func(i, j int) bool {
+		return languages[i].quality > languages[j].quality
+	})
+	tags := make([]string, 0, len(languages))
+	for _, l := range languages {
+		tags = append(tags, l.tag)
+	}
+	return tags
+}
@@ -0,0 +1,118 @@
+package i18n
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   string
+		wantTags []string
+	}{
+		{
+			name:     "given an empty header, when we parse it, no tag should be returned",
+			header:   "",
+			wantTags: []string{},
+		},
+		{
+			name:     "given a header with qualities, when we parse it, the tags should be ordered by quality",
+			header:   "en;q=0.5, es-ES, fr;q=0.8",
+			wantTags: []string{"es-ES", "fr", "en"},
+		},
+		{
+			name:     "given a header with the same qualities, when we parse it, the order of the header should be kept",
+			header:   "fr, es, en",
+			wantTags: []string{"fr", "es", "en"},
+		},
+		{
+			name:     "given a header with a wildcard, zero and malformed qualities, when we parse it, they should be dropped",
+			header:   "*, de;q=0, it;q=high, es;q=0.1",
+			wantTags: []string{"es"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// act
+			tags := ParseAcceptLanguage(tt.header)
+
+			// assert
+			require.Equal(t, tt.wantTags, tags)
+		})
+	}
+}
+
+func TestTranslatorFromRequest(t *testing.T) {
+	translator := MustNew()
+
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		wantLocale     string
+	}{
+		{
+			name:       "given a request without Accept-Language, when we get its translator, the fallback should be returned",
+			wantLocale: English,
+		},
+		{
+			name:           "given a request preferring a regional Spanish, when we get its translator, the Spanish one should be returned",
+			acceptLanguage: "es-MX,es;q=0.9,en;q=0.8",
+			wantLocale:     Spanish,
+		},
+		{
+			name:           "given a request preferring an unsupported language, when we get its translator, the next supported one should be returned",
+			acceptLanguage: "fr-FR, es;q=0.7, en;q=0.3",
+			wantLocale:     Spanish,
+		},
+		{
+			name:           "given a request with only unsupported languages, when we get its translator, the fallback should be returned",
+			acceptLanguage: "de, ja",
+			wantLocale:     English,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			r, err := http.NewRequest(http.MethodGet, "/", nil)
+			require.NoError(t, err)
+			if tt.acceptLanguage != "" {
+				r.Header.Set("Accept-Language", tt.acceptLanguage)
+			}
+
+			// act
+			trans := translator.FromRequest(r)
+
+			// assert
+			require.Equal(t, tt.wantLocale, trans.Locale())
+		})
+	}
+}
+
+func TestMessage(t *testing.T) {
+	translator := MustNew()
+	en := translator.Get(English)
+	es := translator.Get(Spanish)
+
+	// assert
+	require.Equal(t, "email is required", Message(en, RuleRequired, "email", ""))
+	require.Equal(t, "email es obligatorio", Message(es, RuleRequired, "email", ""))
+	require.Equal(t, "name must be at least 3 characters long", Message(en, RuleMinLength, "name", "3"))
+	require.Equal(t, "name debe tener al menos 3 caracteres", Message(es, RuleMinLength, "name", "3"))
+	require.Equal(t, "value must be of type object", Message(en, RuleType, "", "object"))
+	require.Equal(t, "valor debe ser de tipo object", Message(es, RuleType, "", "object"))
+	require.Equal(t, "id no es válido", Message(es, "discriminator", "id", ""), "unknown rules should fall back to the invalid message")
+	require.Equal(t, "la petición tiene 2 errores de validación", Summary(es, 2))
+}
+
+func TestCatalogsHaveTheSameKeys(t *testing.T) {
+	for key := range catalog[English] {
+		_, ok := catalog[Spanish][key]
+		require.True(t, ok, "the %q message should be translated to Spanish", key)
+	}
+	require.Len(t, catalog[Spanish], len(catalog[English]))
+}
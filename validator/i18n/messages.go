@@ -0,0 +1,139 @@
+package i18n
+
+import (
+	"fmt"
+	"strconv"
+
+	ut "github.com/go-playground/universal-translator"
+)
+
+// The rules with a message, in the OpenAPI vocabulary shared by the validators, plus the
+// go-playground tags that have no OpenAPI equivalent.
+const (
+	RuleRequired             = "required"
+	RuleFormat               = "format"
+	RuleEnum                 = "enum"
+	RuleType                 = "type"
+	RuleNullable             = "nullable"
+	RuleMinLength            = "minLength"
+	RuleMaxLength            = "maxLength"
+	RuleLength               = "length"
+	RuleMinimum              = "minimum"
+	RuleMaximum              = "maximum"
+	RuleExclusiveMinimum     = "exclusiveMinimum"
+	RuleExclusiveMaximum     = "exclusiveMaximum"
+	RuleMultipleOf           = "multipleOf"
+	RuleMinItems             = "minItems"
+	RuleMaxItems             = "maxItems"
+	RuleItems                = "items"
+	RuleUniqueItems          = "uniqueItems"
+	RuleMinProperties        = "minProperties"
+	RuleMaxProperties        = "maxProperties"
+	RuleAdditionalProperties = "additionalProperties"
+	RulePattern              = "pattern"
+	RuleEqual                = "equal"
+	RuleNotEqual             = "notEqual"
+	RuleAlpha                = "alpha"
+	RuleAlphanumeric         = "alphanum"
+	RuleNumeric              = "numeric"
+	RuleInvalid              = "invalid"
+)
+
+// The keys of the messages that are not about a rule: the name given to the whole validated
+// value, such as the body, and the summary of the validation errors of a request.
+const (
+	rootKey    = "root"
+	summaryKey = "summary"
+)
+
+// catalog holds the messages of every locale, where {0} is the name of the field and {1}
+// the parameter of the rule.
+var catalog = map[string]map[string]string{
+	English: {
+		rootKey:                  "value",
+		summaryKey:               "the request has {0} validation errors",
+		RuleRequired:             "{0} is required",
+		RuleFormat:               "{0} must be a valid {1}",
+		RuleEnum:                 "{0} must be one of [{1}]",
+		RuleType:                 "{0} must be of type {1}",
+		RuleNullable:             "{0} must not be null",
+		RuleMinLength:            "{0} must be at least {1} characters long",
+		RuleMaxLength:            "{0} must be at most {1} characters long",
+		RuleLength:               "{0} must be exactly {1} characters long",
+		RuleMinimum:              "{0} must be {1} or greater",
+		RuleMaximum:              "{0} must be {1} or less",
+		RuleExclusiveMinimum:     "{0} must be greater than {1}",
+		RuleExclusiveMaximum:     "{0} must be less than {1}",
+		RuleMultipleOf:           "{0} must be a multiple of {1}",
+		RuleMinItems:             "{0} must contain at least {1} items",
+		RuleMaxItems:             "{0} must contain at most {1} items",
+		RuleItems:                "{0} must contain exactly {1} items",
+		RuleUniqueItems:          "{0} must contain unique items",
+		RuleMinProperties:        "{0} must have at least {1} properties",
+		RuleMaxProperties:        "{0} must have at most {1} properties",
+		RuleAdditionalProperties: "{0} is not allowed",
+		RulePattern:              "{0} must match the pattern {1}",
+		RuleEqual:                "{0} must be equal to {1}",
+		RuleNotEqual:             "{0} must not be equal to {1}",
+		RuleAlpha:                "{0} can only contain letters",
+		RuleAlphanumeric:         "{0} can only contain letters and numbers",
+		RuleNumeric:              "{0} must be a numeric value",
+		RuleInvalid:              "{0} is invalid",
+	},
+	Spanish: {
+		rootKey:                  "valor",
+		summaryKey:               "la petición tiene {0} errores de validación",
+		RuleRequired:             "{0} es obligatorio",
+		RuleFormat:               "{0} debe ser un {1} válido",
+		RuleEnum:                 "{0} debe ser uno de [{1}]",
+		RuleType:                 "{0} debe ser de tipo {1}",
+		RuleNullable:             "{0} no puede ser nulo",
+		RuleMinLength:            "{0} debe tener al menos {1} caracteres",
+		RuleMaxLength:            "{0} debe tener como máximo {1} caracteres",
+		RuleLength:               "{0} debe tener exactamente {1} caracteres",
+		RuleMinimum:              "{0} debe ser {1} o mayor",
+		RuleMaximum:              "{0} debe ser {1} o menor",
+		RuleExclusiveMinimum:     "{0} debe ser mayor que {1}",
+		RuleExclusiveMaximum:     "{0} debe ser menor que {1}",
+		RuleMultipleOf:           "{0} debe ser múltiplo de {1}",
+		RuleMinItems:             "{0} debe contener al menos {1} elementos",
+		RuleMaxItems:             "{0} debe contener como máximo {1} elementos",
+		RuleItems:                "{0} debe contener exactamente {1} elementos",
+		RuleUniqueItems:          "{0} debe contener elementos únicos",
+		RuleMinProperties:        "{0} debe tener al menos {1} propiedades",
+		RuleMaxProperties:        "{0} debe tener como máximo {1} propiedades",
+		RuleAdditionalProperties: "{0} no está permitido",
+		RulePattern:              "{0} debe coincidir con el patrón {1}",
+		RuleEqual:                "{0} debe ser igual a {1}",
+		RuleNotEqual:             "{0} no puede ser igual a {1}",
+		RuleAlpha:                "{0} solo puede contener letras",
+		RuleAlphanumeric:         "{0} solo puede contener letras y números",
+		RuleNumeric:              "{0} debe ser un valor numérico",
+		RuleInvalid:              "{0} no es válido",
+	},
+}
+
+// Message returns the localized message of a rule for the field, or of the invalid rule if
+// the rule has no message. An empty field is named after the whole validated value.
+func Message(trans ut.Translator, rule, field, param string) string {
+	if field == "" {
+		field = translate(trans, rootKey)
+	}
+	if msg, err := trans.T(rule, field, param); err == nil {
+		return msg
+	}
+	return translate(trans, RuleInvalid, field)
+}
+
+// Summary returns the localized summary of a request with several validation errors.
+func Summary(trans ut.Translator, count int) string {
+	return translate(trans, summaryKey, strconv.Itoa(count))
+}
+
+func translate(trans ut.Translator, key string, params ...string) string {
+	msg, err := trans.T(key, params...)
+	if err != nil {
+		return fmt.Sprintf("%s %v", key, params)
+	}
+	return msg
+}
@@ -0,0 +1,143 @@
+package i18n
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator"
+
+	"request_validator/validator/formats"
+)
+
+// playgroundFormats are the go-playground tags of string formats that are not in the formats
+// library, by the name of their format.
+var playgroundFormats = map[string]string{
+	"uuid3":         formats.UUID,
+	"uuid4":         formats.UUID,
+	"uuid5":         formats.UUID,
+	"uuid_rfc4122":  formats.UUID,
+	"uuid3_rfc4122": formats.UUID,
+	"uuid4_rfc4122": formats.UUID,
+	"uuid5_rfc4122": formats.UUID,
+	"url":           "url",
+	"ip":            "ip",
+	"base64url":     "base64url",
+	"hexadecimal":   "hexadecimal",
+	"hexcolor":      "hexcolor",
+	"mac":           "mac",
+}
+
+// playgroundRules are the go-playground tags with a message, other than the formats.
+var playgroundRules = []string{
+	"required", "oneof", "min", "max", "len", "gt", "gte", "lt", "lte", "eq", "ne", "unique",
+	"alpha", "alphanum", "numeric", "pattern",
+}
+
+// RegisterTranslations registers the messages of every locale of the translator for the
+// tags of the go-playground validator, so the ValidationErrors can be translated with their
+// Translate method.
+func RegisterTranslations(v *validator.Validate, t *Translator) error {
+	tags := append([]string(nil), playgroundRules...)
+	for tag := range playgroundFormats {
+		tags = append(tags, tag)
+	}
+	for name := range formats.All() {
+		tags = append(tags, name)
+	}
+
+	// the messages are already loaded in the translators
+	loaded := func(ut.Translator) error { return nil }
+	for _, trans := range t.Translators() {
+		for _, tag := range tags {
+			if err := v.RegisterTranslation(tag, trans, loaded, TranslateFieldError); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// TranslateFieldError returns the localized message of a go-playground validation error,
+// mapping its tag to the rule of the OpenAPI vocabulary with the same meaning.
+func TranslateFieldError(trans ut.Translator, fe validator.FieldError) string {
+	return FieldErrorMessage(trans, fe, fe.Field())
+}
+
+// FieldErrorMessage returns the localized message of a go-playground validation error for
+// the field with the given name, such as the name of a request param.
+func FieldErrorMessage(trans ut.Translator, fe validator.FieldError, field string) string {
+	rule, param := fieldErrorRule(fe)
+	return Message(trans, rule, field, param)
+}
+
+func fieldErrorRule(fe validator.FieldError) (string, string) {
+	tag, param := fe.Tag(), fe.Param()
+	if name, ok := playgroundFormats[tag]; ok {
+		return RuleFormat, name
+	}
+	if _, ok := formats.Lookup(tag); ok {
+		return RuleFormat, tag
+	}
+
+	switch tag {
+	case "required":
+		return RuleRequired, ""
+	case "oneof":
+		return RuleEnum, strings.Join(strings.Fields(param), ", ")
+	case "min", "gte":
+		return boundRule(fe.Kind(), RuleMinLength, RuleMinItems, RuleMinimum), param
+	case "max", "lte":
+		return boundRule(fe.Kind(), RuleMaxLength, RuleMaxItems, RuleMaximum), param
+	case "len":
+		return boundRule(fe.Kind(), RuleLength, RuleItems, RuleEqual), param
+	case "gt":
+		// a length greater than n is a length of at least n+1
+		if rule := boundRule(fe.Kind(), RuleMinLength, RuleMinItems, ""); rule != "" {
+			return rule, shiftBound(param, 1)
+		}
+		return RuleExclusiveMinimum, param
+	case "lt":
+		if rule := boundRule(fe.Kind(), RuleMaxLength, RuleMaxItems, ""); rule != "" {
+			return rule, shiftBound(param, -1)
+		}
+		return RuleExclusiveMaximum, param
+	case "eq":
+		return RuleEqual, param
+	case "ne":
+		return RuleNotEqual, param
+	case "unique":
+		return RuleUniqueItems, ""
+	case "alpha":
+		return RuleAlpha, ""
+	case "alphanum":
+		return RuleAlphanumeric, ""
+	case "numeric":
+		return RuleNumeric, ""
+	case "pattern":
+		return RulePattern, param
+	}
+	return RuleInvalid, ""
+}
+
+// boundRule returns the rule of a bound on the length of the strings, the number of items of
+// the slices, arrays and maps, or the value of the numbers.
+func boundRule(kind reflect.Kind, length, items, value string) string {
+	switch kind {
+	case reflect.String:
+		return length
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return items
+	default:
+		return value
+	}
+}
+
+func shiftBound(param string, delta int) string {
+	n, err := strconv.Atoi(param)
+	if err != nil {
+		return param
+	}
+	return strconv.Itoa(n + delta)
+}
@@ -0,0 +1,83 @@
+package i18n
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/go-playground/validator"
+	"github.com/stretchr/testify/require"
+)
+
+type recruitReq struct {
+	ID       string            `json:"id" validate:"required,uuid_rfc4122"`
+	Name     string            `json:"name" validate:"min=3"`
+	Age      int               `json:"age" validate:"gte=16,lt=80"`
+	Role     string            `json:"role" validate:"oneof=ranger builder steward"`
+	Oaths    []string          `json:"oaths" validate:"gt=1,unique"`
+	Currency string            `json:"currency" validate:"iso4217"`
+	Labels   map[string]string `json:"labels" validate:"max=1"`
+}
+
+func TestTranslateFieldError(t *testing.T) {
+	translator := MustNew()
+	v := validator.New()
+	v.RegisterTagNameFunc(func(field reflect.StructField) string { return field.Tag.Get("json") })
+	// the formats library tags are registered by the go-playground validator implementation
+	require.NoError(t, v.RegisterValidation("iso4217", func(fl validator.FieldLevel) bool { return false }))
+	require.NoError(t, RegisterTranslations(v, translator))
+
+	err := v.Struct(recruitReq{
+		ID:     "ghost",
+		Name:   "Ed",
+		Age:    90,
+		Role:   "lord",
+		Oaths:  []string{"watch"},
+		Labels: map[string]string{"a": "1", "b": "2"},
+	})
+	var validationErrors validator.ValidationErrors
+	require.True(t, errors.As(err, &validationErrors), "error should be of type validator.ValidationErrors")
+
+	tests := []struct {
+		name     string
+		locale   string
+		wantMsgs map[string]string
+	}{
+		{
+			name:   "given the English translator, when we translate the errors, the English messages should be returned",
+			locale: English,
+			wantMsgs: map[string]string{
+				"recruitReq.id":       "id must be a valid uuid",
+				"recruitReq.name":     "name must be at least 3 characters long",
+				"recruitReq.age":      "age must be less than 80",
+				"recruitReq.role":     "role must be one of [ranger, builder, steward]",
+				"recruitReq.oaths":    "oaths must contain at least 2 items",
+				"recruitReq.currency": "currency must be a valid iso4217",
+				"recruitReq.labels":   "labels must contain at most 1 items",
+			},
+		},
+		{
+			name:   "given the Spanish translator, when we translate the errors, the Spanish messages should be returned",
+			locale: Spanish,
+			wantMsgs: map[string]string{
+				"recruitReq.id":       "id debe ser un uuid válido",
+				"recruitReq.name":     "name debe tener al menos 3 caracteres",
+				"recruitReq.age":      "age debe ser menor que 80",
+				"recruitReq.role":     "role debe ser uno de [ranger, builder, steward]",
+				"recruitReq.oaths":    "oaths debe contener al menos 2 elementos",
+				"recruitReq.currency": "currency debe ser un iso4217 válido",
+				"recruitReq.labels":   "labels debe contener como máximo 1 elementos",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// act
+			msgs := validationErrors.Translate(translator.Get(tt.locale))
+
+			// assert
+			require.Equal(t, validator.ValidationErrorsTranslations(tt.wantMsgs), msgs)
+		})
+	}
+}
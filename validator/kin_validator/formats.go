@@ -0,0 +1,374 @@
+package kinvalidator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+
+	"request_validator/validator/formats"
+)
+
+// The kin-openapi library defines the byte, date and date-time formats in its process wide
+// registry, which is checked before the format registry of the validators. They are replaced
+// by validators that accept every value, so these formats are only checked by the format
+// registry with the rules of the formats library, or with the ones given to the validator.
+func init() {
+	acceptAll := openapi3.NewCallbackValidator(func(string) error { return nil })
+	for name := range formats.All() {
+		if _, ok := openapi3.SchemaStringFormats[name]; ok {
+			openapi3.DefineStringFormatValidator(name, acceptAll)
+		}
+	}
+}
+
+// FormatError is returned when a value doesn't match one of the format validators of the
+// validator. It wraps the openapi3.SchemaError describing the violation.
+type FormatError struct {
+	pointer []string
+	Err     *openapi3.SchemaError
+}
+
+func (e *FormatError) Error() string {
+	if len(e.pointer) == 0 {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf(`Error at "/%s": %s`, strings.Join(e.pointer, "/"), e.Err.Error())
+}
+
+func (e *FormatError) Unwrap() error {
+	return e.Err
+}
+
+// JSONPointer returns the path to the value that doesn't match the format.
+func (e *FormatError) JSONPointer() []string {
+	return append([]string(nil), e.pointer...)
+}
+
+// formatRegistry holds the string format validators of a single validator. The kin-openapi
+// library only supports a process wide registry of formats, which can't be changed safely
+// while other validators are in use, so the formats are checked by the validator itself
+// once the request follows the rest of the schema.
+type formatRegistry struct {
+	validators map[string]openapi3.StringFormatValidator
+	multiError bool
+
+	// usage caches whether a schema, or any of its sub-schemas, has a registered format.
+	usage sync.Map
+}
+
+func newFormatRegistry(validators map[string]openapi3.StringFormatValidator, multiError bool) *formatRegistry {
+	return &formatRegistry{validators: validators, multiError: multiError}
+}
+
+// validateRequest checks the formats of the primitive params and of the JSON body of the request.
+func (f *formatRegistry) validateRequest(input *openapi3filter.RequestValidationInput) []error {
+	if len(f.validators) == 0 {
+		return nil
+	}
+
+	var errs []error
+	for _, p := range routeParameters(input.Route) {
+		if f.done(errs) {
+			return errs
+		}
+		if err := f.validateParameter(input, p); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if f.done(errs) || (input.Options != nil && input.Options.ExcludeRequestBody) {
+		return errs
+	}
+	if err := f.validateBody(input); err != nil {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+func (f *formatRegistry) done(errs []error) bool {
+	return len(errs) > 0 && !f.multiError
+}
+
+func (f *formatRegistry) validateParameter(input *openapi3filter.RequestValidationInput, p *openapi3.Parameter) error {
+	if p.Schema == nil || p.Schema.Value == nil || p.Schema.Value.Format == "" {
+		return nil
+	}
+	validator, ok := f.validators[p.Schema.Value.Format]
+	if !ok {
+		return nil
+	}
+
+	var value string
+	var found bool
+	switch p.In {
+	case openapi3.ParameterInPath:
+		value, found = input.PathParams[p.Name]
+		if unescaped, err := url.PathUnescape(value); err == nil {
+			value = unescaped
+		}
+	case openapi3.ParameterInQuery:
+		var values []string
+		values, found = input.Request.URL.Query()[p.Name]
+		if found && len(values) > 0 {
+			value = values[0]
+		}
+	case openapi3.ParameterInHeader:
+		value = input.Request.Header.Get(p.Name)
+		found = value != ""
+	case openapi3.ParameterInCookie:
+		if c, err := input.Request.Cookie(p.Name); err == nil {
+			value, found = c.Value, true
+		}
+	}
+	if !found {
+		return nil
+	}
+
+	if err := f.validateString(p.Schema.Value, validator, value, nil); err != nil {
+		return &openapi3filter.RequestError{Input: input, Parameter: p, Err: err}
+	}
+	return nil
+}
+
+func (f *formatRegistry) validateBody(input *openapi3filter.RequestValidationInput) error {
+	route := input.Route
+	if route == nil || route.Operation == nil || route.Operation.RequestBody == nil || route.Operation.RequestBody.Value == nil {
+		return nil
+	}
+	requestBody := route.Operation.RequestBody.Value
+
+	req := input.Request
+	schema := f.jsonSchema(requestBody.Content, req.Header.Get("Content-Type"))
+	if schema == nil || req.Body == nil || req.Body == http.NoBody {
+		return nil
+	}
+	data, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	req.Body = io.NopCloser(bytes.NewReader(data))
+	if err != nil {
+		return nil
+	}
+
+	errs := f.validateJSON(schema, data)
+	if len(errs) == 0 {
+		return nil
+	}
+	return &openapi3filter.RequestError{Input: input, RequestBody: requestBody, Reason: "doesn't match schema", Err: f.collect(errs)}
+}
+
+// validateResponse checks the formats of the JSON body of the response.
+func (f *formatRegistry) validateResponse(input *openapi3filter.ResponseValidationInput, body []byte) error {
+	route := input.RequestValidationInput.Route
+	if len(f.validators) == 0 || route == nil || route.Operation == nil || route.Operation.Responses == nil {
+		return nil
+	}
+	responseRef := route.Operation.Responses.Status(input.Status)
+	if responseRef == nil {
+		responseRef = route.Operation.Responses.Default()
+	}
+	if responseRef == nil || responseRef.Value == nil {
+		return nil
+	}
+
+	schema := f.jsonSchema(responseRef.Value.Content, input.Header.Get("Content-Type"))
+	if schema == nil {
+		return nil
+	}
+	errs := f.validateJSON(schema, body)
+	if len(errs) == 0 {
+		return nil
+	}
+	return &openapi3filter.ResponseError{Input: input, Reason: "response body doesn't match schema", Err: f.collect(errs)}
+}
+
+// jsonSchema returns the schema of the content with the given JSON content type, if it has
+// any registered format.
+func (f *formatRegistry) jsonSchema(content openapi3.Content, contentType string) *openapi3.Schema {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil || !(mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")) {
+		return nil
+	}
+	mediaTypeContent := content.Get(contentType)
+	if mediaTypeContent == nil || mediaTypeContent.Schema == nil || mediaTypeContent.Schema.Value == nil || !f.uses(mediaTypeContent.Schema.Value, nil) {
+		return nil
+	}
+	return mediaTypeContent.Schema.Value
+}
+
+func (f *formatRegistry) validateJSON(schema *openapi3.Schema, data []byte) openapi3.MultiError {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		// the body was already accepted by the schema validation, so it can only fail on
+		// a body that the schema does not describe
+		return nil
+	}
+
+	var errs openapi3.MultiError
+	f.visit(schema, value, nil, &errs)
+	return errs
+}
+
+// collect returns every error in multi error mode and the first one otherwise.
+func (f *formatRegistry) collect(errs openapi3.MultiError) error {
+	if f.multiError {
+		return errs
+	}
+	return errs[0]
+}
+
+func (f *formatRegistry) visit(schema *openapi3.Schema, value interface{}, path []string, errs *openapi3.MultiError) {
+	if schema == nil || value == nil || f.done(*errs) || !f.uses(schema, nil) {
+		return
+	}
+
+	for _, sub := range schema.AllOf {
+		f.visitRef(sub, value, path, errs)
+	}
+	for _, subs := range []openapi3.SchemaRefs{schema.AnyOf, schema.OneOf} {
+		for _, sub := range subs {
+			// only the sub-schemas the value matches apply to it
+			if sub.Value != nil && sub.Value.VisitJSON(value) == nil {
+				f.visitRef(sub, value, path, errs)
+			}
+		}
+	}
+
+	switch v := value.(type) {
+	case string:
+		if validator, ok := f.validators[schema.Format]; ok {
+			if err := f.validateString(schema, validator, v, path); err != nil {
+				*errs = append(*errs, err)
+			}
+		}
+	case map[string]interface{}:
+		// the keys are sorted so the violations are always reported in the same order
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			field := v[key]
+			fieldPath := append(append([]string(nil), path...), key)
+			if prop, ok := schema.Properties[key]; ok {
+				f.visitRef(prop, field, fieldPath, errs)
+			} else if additional := schema.AdditionalProperties.Schema; additional != nil {
+				f.visitRef(additional, field, fieldPath, errs)
+			}
+		}
+	case []interface{}:
+		if schema.Items == nil {
+			return
+		}
+		for i, item := range v {
+			f.visitRef(schema.Items, item, append(append([]string(nil), path...), fmt.Sprint(i)), errs)
+		}
+	}
+}
+
+func (f *formatRegistry) visitRef(ref *openapi3.SchemaRef, value interface{}, path []string, errs *openapi3.MultiError) {
+	if ref != nil {
+		f.visit(ref.Value, value, path, errs)
+	}
+}
+
+func (f *formatRegistry) validateString(schema *openapi3.Schema, validator openapi3.StringFormatValidator, value string, path []string) error {
+	err := validator.Validate(value)
+	if err == nil {
+		return nil
+	}
+	return &FormatError{
+		pointer: path,
+		Err: &openapi3.SchemaError{
+			Value:       value,
+			Schema:      schema,
+			SchemaField: "format",
+			Reason:      fmt.Sprintf("string doesn't match the format %q (%v)", schema.Format, err),
+			Origin:      fmt.Errorf("string doesn't match the format %q: %w", schema.Format, err),
+		},
+	}
+}
+
+// uses reports whether the schema, or any of its sub-schemas, has a registered format.
+func (f *formatRegistry) uses(schema *openapi3.Schema, visiting map[*openapi3.Schema]bool) bool {
+	if cached, ok := f.usage.Load(schema); ok {
+		return cached.(bool)
+	}
+	// only the outermost call knows the whole graph of a recursive schema, so it is the
+	// only one whose result is cached
+	outermost := visiting == nil
+	if outermost {
+		visiting = make(map[*openapi3.Schema]bool)
+	}
+	if visiting[schema] {
+		// recursive schemas are resolved by the outermost call
+		return false
+	}
+	visiting[schema] = true
+
+	_, used := f.validators[schema.Format]
+	refs := make([]*openapi3.SchemaRef, 0, len(schema.Properties)+len(schema.AllOf)+len(schema.AnyOf)+len(schema.OneOf)+2)
+	for _, prop := range schema.Properties {
+		refs = append(refs, prop)
+	}
+	refs = append(refs, schema.AllOf...)
+	refs = append(refs, schema.AnyOf...)
+	refs = append(refs, schema.OneOf...)
+	refs = append(refs, schema.Items, schema.AdditionalProperties.Schema)
+	for _, ref := range refs {
+		if used {
+			break
+		}
+		if ref != nil && ref.Value != nil {
+			used = f.uses(ref.Value, visiting)
+		}
+	}
+
+	if outermost {
+		f.usage.Store(schema, used)
+	}
+	return used
+}
+
+// routeParameters returns the params of the route, where the params of the operation
+// override the ones of its path with the same location and name.
+func routeParameters(route *routers.Route) []*openapi3.Parameter {
+	if route == nil {
+		return nil
+	}
+
+	var refs openapi3.Parameters
+	if route.PathItem != nil {
+		refs = append(refs, route.PathItem.Parameters...)
+	}
+	if route.Operation != nil {
+		refs = append(refs, route.Operation.Parameters...)
+	}
+
+	type key struct{ in, name string }
+	index := make(map[key]int, len(refs))
+	params := make([]*openapi3.Parameter, 0, len(refs))
+	for _, ref := range refs {
+		if ref == nil || ref.Value == nil {
+			continue
+		}
+		k := key{in: ref.Value.In, name: ref.Value.Name}
+		if i, ok := index[k]; ok {
+			params[i] = ref.Value
+			continue
+		}
+		index[k] = len(params)
+		params = append(params, ref.Value)
+	}
+	return params
+}
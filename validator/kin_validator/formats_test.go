@@ -0,0 +1,221 @@
+package kinvalidator
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	api "request_validator/http/v1"
+	"sync"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/stretchr/testify/require"
+)
+
+const teamsSpec = `
+openapi: 3.0.0
+info:
+  title: Teams API
+  version: 0.1.0
+servers:
+  - url: http://api.example.com/v1
+paths:
+  /teams/{teamId}/members:
+    parameters:
+      - name: teamId
+        in: path
+        required: true
+        schema:
+          type: string
+          format: uuid
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                members:
+                  type: array
+                  items:
+                    type: object
+                    properties:
+                      email:
+                        type: string
+                        format: email
+                      joinedAt:
+                        type: string
+                        format: date-time
+      responses:
+        '200':
+          description: The members were added
+`
+
+func TestFormatValidators(t *testing.T) {
+	ctx := context.Background()
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(teamsSpec))
+	require.NoError(t, err, "spec loading should not error")
+	validator, err := NewValidator(ctx, doc)
+	require.NoError(t, err, "validator creation should not error")
+
+	tests := []struct {
+		name     string
+		url      string
+		req      string
+		wantFunc func(t *testing.T, err error)
+	}{
+		{
+			name:     "given a valid path param and body, when we try to validate it, no error should be returned",
+			url:      "http://api.example.com/v1/teams/32d3e8f1-2f81-49c0-acb6-6dccd84f3dab/members",
+			req:      `{"members": [{"email": "jon_snow@winterfell.com"}]}`,
+			wantFunc: func(t *testing.T, err error) { require.NoError(t, err, "validator should not error") },
+		},
+		{
+			name: "given a path param that is not of a UUID type, when we try to validate it, a format error of the param should be returned",
+			url:  "http://api.example.com/v1/teams/winterfell/members",
+			req:  `{"members": []}`,
+			wantFunc: func(t *testing.T, err error) {
+				var requestErr *openapi3filter.RequestError
+				require.True(t, errors.As(err, &requestErr), "error should be of type RequestError")
+				require.Equal(t, "teamId", requestErr.Parameter.Name)
+				var formatErr *FormatError
+				require.True(t, errors.As(err, &formatErr), "error should be of type FormatError")
+			},
+		},
+		{
+			name: "given a nested field with an invalid email format, when we try to validate it, a format error with its path should be returned",
+			url:  "http://api.example.com/v1/teams/32d3e8f1-2f81-49c0-acb6-6dccd84f3dab/members",
+			req:  `{"members": [{"email": "jon_snow@winterfell.com"}, {"email": "this_is_a_test"}]}`,
+			wantFunc: func(t *testing.T, err error) {
+				var formatErr *FormatError
+				require.True(t, errors.As(err, &formatErr), "error should be of type FormatError")
+				require.Equal(t, []string{"members", "1", "email"}, formatErr.JSONPointer())
+				var schemaErr *openapi3.SchemaError
+				require.True(t, errors.As(err, &schemaErr), "error should be of type SchemaError")
+				require.Equal(t, "format", schemaErr.SchemaField)
+				require.Equal(t, "this_is_a_test", schemaErr.Value)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, tt.url, bytes.NewReader([]byte(tt.req)))
+			require.NoError(t, err, "http request creation should not error")
+			httpRequest.Header.Add("Content-Type", "application/json")
+
+			// act
+			err = validator.ValidateRequest(ctx, httpRequest)
+
+			// assert
+			tt.wantFunc(t, err)
+		})
+	}
+}
+
+func TestStringFormatValidatorOfKinFormats(t *testing.T) {
+	ctx := context.Background()
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(teamsSpec))
+	require.NoError(t, err, "spec loading should not error")
+	acceptAll := openapi3.NewCallbackValidator(func(string) error { return nil })
+
+	tests := []struct {
+		name     string
+		opts     []Option
+		joinedAt string
+		wantFunc func(t *testing.T, err error)
+	}{
+		{
+			name:     "given the default validator and a date-time with lower case separators, when we try to validate it, it should be accepted as the formats library does",
+			joinedAt: "2017-07-21t17:32:28z",
+			wantFunc: func(t *testing.T, err error) { require.NoError(t, err, "validator should not error") },
+		},
+		{
+			name:     "given the default validator and a value that is not a date-time, when we try to validate it, a format error should be returned",
+			joinedAt: "anything",
+			wantFunc: func(t *testing.T, err error) {
+				var formatErr *FormatError
+				require.True(t, errors.As(err, &formatErr), "error should be of type FormatError")
+				require.Equal(t, []string{"members", "0", "joinedAt"}, formatErr.JSONPointer())
+			},
+		},
+		{
+			name:     "given a validator that overrides the date-time format to accept every value, when we try to validate a value that is not a date-time, it should be accepted",
+			opts:     []Option{WithStringFormatValidator("date-time", acceptAll)},
+			joinedAt: "anything",
+			wantFunc: func(t *testing.T, err error) { require.NoError(t, err, "validator should not error") },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			validator, err := NewValidator(ctx, doc, tt.opts...)
+			require.NoError(t, err, "validator creation should not error")
+			httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://api.example.com/v1/teams/32d3e8f1-2f81-49c0-acb6-6dccd84f3dab/members", bytes.NewReader([]byte(`{"members": [{"joinedAt": "`+tt.joinedAt+`"}]}`)))
+			require.NoError(t, err, "http request creation should not error")
+			httpRequest.Header.Add("Content-Type", "application/json")
+
+			// act
+			err = validator.ValidateRequest(ctx, httpRequest)
+
+			// assert
+			tt.wantFunc(t, err)
+		})
+	}
+}
+
+// TestConcurrentFormatValidators is meant to be run with the race detector, which would
+// report the validators mutating the format registry of the kin-openapi library.
+func TestConcurrentFormatValidators(t *testing.T) {
+	ctx := context.Background()
+	const upperCaseEmailRequest = `
+	{
+		"id": "32d3e8f1-2f81-49c0-acb6-6dccd84f3dab",
+		"firstName": "Jon",
+		"lastName": "Snow",
+		"email": "Jon.Snow@Winterfell.COM"
+	}`
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		lenient := i%2 == 0
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			// arrange
+			swaggerDoc, err := api.GetSwagger()
+			require.NoError(t, err, "swagger recovery should not error")
+			var opts []Option
+			if !lenient {
+				opts = append(opts, WithStringFormatValidator("email", openapi3.NewRegexpFormatValidator(`^[a-z0-9._%+\-]+@[a-z0-9.\-]+\.[a-z]{2,4}$`)))
+			}
+			validator, err := NewValidator(ctx, swaggerDoc, opts...)
+			require.NoError(t, err, "validator creation should not error")
+
+			for j := 0; j < 10; j++ {
+				httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://api.example.com/v1/users/create", bytes.NewReader([]byte(upperCaseEmailRequest)))
+				require.NoError(t, err, "http request creation should not error")
+				httpRequest.Header.Add("Content-Type", "application/json")
+
+				// act
+				err = validator.ValidateRequest(ctx, httpRequest)
+
+				// assert
+				if lenient {
+					require.NoError(t, err, "the default validator should accept upper case emails")
+				} else {
+					require.Error(t, err, "the strict validator should reject upper case emails")
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	_, defined := openapi3.SchemaStringFormats["email"]
+	require.False(t, defined, "the validators should not define global formats")
+}
@@ -0,0 +1,307 @@
+package kinvalidator
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rsa"
+	_ "crypto/sha256" // registers the SHA-256 hash used by the *256 algorithms
+	_ "crypto/sha512" // registers the SHA-384 and SHA-512 hashes used by the *384 and *512 algorithms
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// JWTOption configures the verification of the tokens of JWTAuthenticator.
+type JWTOption func(*jwtVerifier)
+
+// WithJWTKey adds a key that verifies the signature of the tokens. The key is a []byte secret
+// for the HS* algorithms, an *rsa.PublicKey for the RS* and PS* ones, an *ecdsa.PublicKey for
+// the ES* ones and an ed25519.PublicKey for EdDSA. The tokens with a "kid" header are only
+// verified with the key of that id; the ones without it are verified with every key.
+func WithJWTKey(kid string, key interface{}) JWTOption {
+	return func(v *jwtVerifier) {
+		v.keys = append(v.keys, jwtKey{kid: kid, key: key})
+	}
+}
+
+// WithJWTIssuer requires the "iss" claim of the tokens to be the given issuer.
+func WithJWTIssuer(issuer string) JWTOption {
+	return func(v *jwtVerifier) {
+		v.issuer = issuer
+	}
+}
+
+// WithJWTAudience requires the "aud" claim of the tokens to contain the given audience.
+func WithJWTAudience(audience string) JWTOption {
+	return func(v *jwtVerifier) {
+		v.audience = audience
+	}
+}
+
+// WithJWTLeeway sets the clock skew tolerated when checking the "exp" and "nbf" claims.
+func WithJWTLeeway(leeway time.Duration) JWTOption {
+	return func(v *jwtVerifier) {
+		v.leeway = leeway
+	}
+}
+
+// WithJWTClock sets the function that returns the current time, which defaults to time.Now.
+func WithJWTClock(now func() time.Time) JWTOption {
+	return func(v *jwtVerifier) {
+		v.now = now
+	}
+}
+
+// JWTAuthenticator returns a BearerAuthenticator that accepts the JSON Web Tokens signed with
+// one of the configured keys which have not expired. The tokens must have an "exp" claim and
+// their scopes are read from the "scope" claim, a space separated string, or the "scp" claim.
+func JWTAuthenticator(opts ...JWTOption) Authenticator {
+	v := &jwtVerifier{now: time.Now}
+	for _, opt := range opts {
+		opt(v)
+	}
+	return BearerAuthenticator(func(ctx context.Context, token string) ([]string, error) {
+		claims, err := v.verify(token)
+		if err != nil {
+			return nil, err
+		}
+		return claims.scopes(), nil
+	})
+}
+
+// ecdsaCurveBits holds the size of the curve of each ES* algorithm.
+var ecdsaCurveBits = map[string]int{"256": 256, "384": 384, "512": 521}
+
+type jwtKey struct {
+	kid string
+	key interface{}
+}
+
+type jwtVerifier struct {
+	keys     []jwtKey
+	issuer   string
+	audience string
+	leeway   time.Duration
+	now      func() time.Time
+}
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+type jwtClaims map[string]interface{}
+
+func (v *jwtVerifier) verify(token string) (jwtClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("the token is not a JWT")
+	}
+
+	var header jwtHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("invalid token header: %w", err)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("invalid token signature: %w", err)
+	}
+	if err := v.verifySignature(header, []byte(parts[0]+"."+parts[1]), signature); err != nil {
+		return nil, err
+	}
+
+	var claims jwtClaims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("invalid token claims: %w", err)
+	}
+	if err := v.verifyClaims(claims); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+func (v *jwtVerifier) verifySignature(header jwtHeader, signed, signature []byte) error {
+	verified := false
+	for _, k := range v.keys {
+		if header.Kid != "" && k.kid != header.Kid {
+			continue
+		}
+		ok, err := verifyJWTSignature(header.Alg, k.key, signed, signature)
+		if err != nil && header.Kid != "" {
+			return err
+		}
+		if ok {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return errors.New("the token signature is not valid")
+	}
+	return nil
+}
+
+func verifyJWTSignature(alg string, key interface{}, signed, signature []byte) (bool, error) {
+	if alg == "EdDSA" {
+		pub, ok := key.(ed25519.PublicKey)
+		if !ok {
+			return false, fmt.Errorf("the key does not support the %q algorithm", alg)
+		}
+		return ed25519.Verify(pub, signed, signature), nil
+	}
+
+	if len(alg) != 5 {
+		return false, fmt.Errorf("unsupported %q algorithm", alg)
+	}
+	var hash crypto.Hash
+	switch alg[2:] {
+	case "256":
+		hash = crypto.SHA256
+	case "384":
+		hash = crypto.SHA384
+	case "512":
+		hash = crypto.SHA512
+	default:
+		return false, fmt.Errorf("unsupported %q algorithm", alg)
+	}
+	h := hash.New()
+	h.Write(signed)
+	digest := h.Sum(nil)
+
+	switch alg[:2] {
+	case "HS":
+		secret, ok := key.([]byte)
+		if !ok {
+			return false, fmt.Errorf("the key does not support the %q algorithm", alg)
+		}
+		mac := hmac.New(hash.New, secret)
+		mac.Write(signed)
+		return hmac.Equal(mac.Sum(nil), signature), nil
+	case "RS", "PS":
+		pub, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return false, fmt.Errorf("the key does not support the %q algorithm", alg)
+		}
+		if alg[0] == 'P' {
+			return rsa.VerifyPSS(pub, hash, digest, signature, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash}) == nil, nil
+		}
+		return rsa.VerifyPKCS1v15(pub, hash, digest, signature) == nil, nil
+	case "ES":
+		pub, ok := key.(*ecdsa.PublicKey)
+		if !ok || pub.Curve.Params().BitSize != ecdsaCurveBits[alg[2:]] {
+			return false, fmt.Errorf("the key does not support the %q algorithm", alg)
+		}
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		if len(signature) != 2*size {
+			return false, nil
+		}
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		return ecdsa.Verify(pub, digest, r, s), nil
+	default:
+		return false, fmt.Errorf("unsupported %q algorithm", alg)
+	}
+}
+
+func (v *jwtVerifier) verifyClaims(claims jwtClaims) error {
+	now := v.now()
+
+	exp, ok, err := claims.numericDate("exp")
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		return errors.New("the token has no expiration time")
+	case !now.Before(exp.Add(v.leeway)):
+		return errors.New("the token has expired")
+	}
+
+	nbf, ok, err := claims.numericDate("nbf")
+	switch {
+	case err != nil:
+		return err
+	case ok && now.Add(v.leeway).Before(nbf):
+		return errors.New("the token is not valid yet")
+	}
+
+	if v.issuer != "" {
+		if iss, _ := claims["iss"].(string); iss != v.issuer {
+			return fmt.Errorf("the token issuer %q is not accepted", iss)
+		}
+	}
+	if v.audience != "" && !contains(claims.strings("aud"), v.audience) {
+		return errors.New("the token audience is not accepted")
+	}
+	return nil
+}
+
+func (c jwtClaims) numericDate(name string) (time.Time, bool, error) {
+	value, ok := c[name]
+	if !ok {
+		return time.Time{}, false, nil
+	}
+	number, ok := value.(json.Number)
+	if !ok {
+		return time.Time{}, false, fmt.Errorf("the %q claim is not a number", name)
+	}
+	seconds, err := number.Float64()
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("the %q claim is not a number", name)
+	}
+	return time.Unix(0, 0).Add(time.Duration(seconds * float64(time.Second))), true, nil
+}
+
+// strings returns the values of a claim that is either a string or an array of strings.
+func (c jwtClaims) strings(name string) []string {
+	switch value := c[name].(type) {
+	case string:
+		return []string{value}
+	case []interface{}:
+		values := make([]string, 0, len(value))
+		for _, v := range value {
+			if s, ok := v.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	default:
+		return nil
+	}
+}
+
+func (c jwtClaims) scopes() []string {
+	if scope, ok := c["scope"].(string); ok {
+		return strings.Fields(scope)
+	}
+	if scp, ok := c["scp"].(string); ok {
+		return strings.Fields(scp)
+	}
+	return c.strings("scp")
+}
+
+func decodeSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,93 @@
+package kinvalidator
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	ut "github.com/go-playground/universal-translator"
+
+	"request_validator/validator/i18n"
+)
+
+// TranslateSchemaError returns the localized message of a schema error, mapping the failed
+// keyword of the schema to the message template of its rule. The field names the invalid
+// value, such as the last token of its pointer or the name of its param, and an empty field
+// names the whole value.
+func TranslateSchemaError(trans ut.Translator, err *openapi3.SchemaError, field string) string {
+	rule, param := schemaRule(err)
+	return i18n.Message(trans, rule, field, param)
+}
+
+func schemaRule(err *openapi3.SchemaError) (string, string) {
+	schema := err.Schema
+	if schema == nil {
+		schema = &openapi3.Schema{}
+	}
+
+	switch err.SchemaField {
+	case "required":
+		return i18n.RuleRequired, ""
+	case "format":
+		return i18n.RuleFormat, schema.Format
+	case "enum":
+		values := make([]string, 0, len(schema.Enum))
+		for _, value := range schema.Enum {
+			values = append(values, fmt.Sprint(value))
+		}
+		return i18n.RuleEnum, strings.Join(values, ", ")
+	case "type":
+		if schema.Type == nil {
+			return i18n.RuleType, ""
+		}
+		return i18n.RuleType, strings.Join(schema.Type.Slice(), ", ")
+	case "nullable":
+		return i18n.RuleNullable, ""
+	case "minLength":
+		return i18n.RuleMinLength, formatUint(schema.MinLength)
+	case "maxLength":
+		return i18n.RuleMaxLength, formatUintPtr(schema.MaxLength)
+	case "minimum":
+		return i18n.RuleMinimum, formatFloatPtr(schema.Min)
+	case "maximum":
+		return i18n.RuleMaximum, formatFloatPtr(schema.Max)
+	case "exclusiveMinimum":
+		return i18n.RuleExclusiveMinimum, formatFloatPtr(schema.Min)
+	case "exclusiveMaximum":
+		return i18n.RuleExclusiveMaximum, formatFloatPtr(schema.Max)
+	case "multipleOf":
+		return i18n.RuleMultipleOf, formatFloatPtr(schema.MultipleOf)
+	case "minItems":
+		return i18n.RuleMinItems, formatUint(schema.MinItems)
+	case "maxItems":
+		return i18n.RuleMaxItems, formatUintPtr(schema.MaxItems)
+	case "uniqueItems":
+		return i18n.RuleUniqueItems, ""
+	case "minProperties":
+		return i18n.RuleMinProperties, formatUint(schema.MinProps)
+	case "maxProperties":
+		return i18n.RuleMaxProperties, formatUintPtr(schema.MaxProps)
+	case "pattern":
+		return i18n.RulePattern, schema.Pattern
+	}
+	return i18n.RuleInvalid, ""
+}
+
+func formatUint(n uint64) string {
+	return strconv.FormatUint(n, 10)
+}
+
+func formatUintPtr(n *uint64) string {
+	if n == nil {
+		return ""
+	}
+	return formatUint(*n)
+}
+
+func formatFloatPtr(f *float64) string {
+	if f == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*f, 'f', -1, 64)
+}
@@ -0,0 +1,80 @@
+package kinvalidator
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/require"
+
+	"request_validator/validator/i18n"
+)
+
+func TestTranslateSchemaError(t *testing.T) {
+	translator := i18n.MustNew()
+
+	tests := []struct {
+		name   string
+		schema *openapi3.Schema
+		value  interface{}
+		field  string
+		wantEN string
+		wantES string
+	}{
+		{
+			name:   "given a string shorter than its min length, when we translate the error, the min length should be in the message",
+			schema: openapi3.NewStringSchema().WithMinLength(3),
+			value:  "Ed",
+			field:  "name",
+			wantEN: "name must be at least 3 characters long",
+			wantES: "name debe tener al menos 3 caracteres",
+		},
+		{
+			name:   "given a number over its exclusive maximum, when we translate the error, the maximum should be in the message",
+			schema: openapi3.NewIntegerSchema().WithMax(80).WithExclusiveMax(true),
+			value:  float64(80),
+			field:  "age",
+			wantEN: "age must be less than 80",
+			wantES: "age debe ser menor que 80",
+		},
+		{
+			name:   "given a value out of its enum, when we translate the error, the enum values should be in the message",
+			schema: openapi3.NewStringSchema().WithEnum("ranger", "builder"),
+			value:  "lord",
+			field:  "role",
+			wantEN: "role must be one of [ranger, builder]",
+			wantES: "role debe ser uno de [ranger, builder]",
+		},
+		{
+			name:   "given a value of the wrong type without field, when we translate the error, the whole value should be named",
+			schema: openapi3.NewObjectSchema(),
+			value:  "guild",
+			wantEN: "value must be of type object",
+			wantES: "valor debe ser de tipo object",
+		},
+		{
+			name:   "given an object without a required property, when we translate the error, the property should be required",
+			schema: openapi3.NewObjectSchema().WithProperty("id", openapi3.NewStringSchema()).WithRequired([]string{"id"}),
+			value:  map[string]interface{}{},
+			field:  "id",
+			wantEN: "id is required",
+			wantES: "id es obligatorio",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			var schemaErr *openapi3.SchemaError
+			require.True(t, errors.As(tt.schema.VisitJSON(tt.value), &schemaErr), "error should be of type openapi3.SchemaError")
+
+			// act
+			msgEN := TranslateSchemaError(translator.Get(i18n.English), schemaErr, tt.field)
+			msgES := TranslateSchemaError(translator.Get(i18n.Spanish), schemaErr, tt.field)
+
+			// assert
+			require.Equal(t, tt.wantEN, msgEN)
+			require.Equal(t, tt.wantES, msgES)
+		})
+	}
+}
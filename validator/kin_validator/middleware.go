@@ -0,0 +1,173 @@
+package kinvalidator
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/getkin/kin-openapi/routers"
+)
+
+// ErrorResponder writes the response of a request that failed the validation.
+type ErrorResponder func(w http.ResponseWriter, r *http.Request, err error)
+
+// MiddlewareOption configures the behaviour of the validation middleware.
+type MiddlewareOption func(*middleware)
+
+// WithErrorResponder sets the function used to reject the invalid requests.
+func WithErrorResponder(responder ErrorResponder) MiddlewareOption {
+	return func(m *middleware) {
+		m.responder = responder
+	}
+}
+
+// WithSkipper sets a function that decides, before the request is routed, if the request
+// should be forwarded to the next handler without being validated.
+func WithSkipper(skipper func(r *http.Request) bool) MiddlewareOption {
+	return func(m *middleware) {
+		m.skipper = skipper
+	}
+}
+
+// WithSkipRoute opts out the operation with the given method and path template, as
+// written in the specification (e.g. "/users/{id}"), from the validation. Its route and
+// path params are still available to the next handler.
+func WithSkipRoute(method, path string) MiddlewareOption {
+	return func(m *middleware) {
+		m.skipRoutes[routeKey{method: method, path: path}] = struct{}{}
+	}
+}
+
+// WithResponseValidation validates the responses of the next handler against the responses
+// of the matched operation. The responses are buffered and written unchanged to the client,
+// and the ones that don't follow the document are passed to the reporter, which defaults to
+// LogResponseReporter if it is nil. The buffering doesn't support streamed responses.
+func WithResponseValidation(reporter ResponseReporter) MiddlewareOption {
+	return func(m *middleware) {
+		if reporter == nil {
+			reporter = LogResponseReporter
+		}
+		m.reporter = reporter
+	}
+}
+
+type routeKey struct {
+	method string
+	path   string
+}
+
+type middleware struct {
+	validator  *Validator
+	responder  ErrorResponder
+	skipper    func(r *http.Request) bool
+	skipRoutes map[routeKey]struct{}
+	reporter   ResponseReporter
+}
+
+// Middleware returns an http middleware that validates every request before forwarding it
+// to the next handler. The matched route and its path params are stored in the request
+// context and can be recovered with RouteFromContext and PathParamsFromContext.
+func (v *Validator) Middleware(opts ...MiddlewareOption) func(http.Handler) http.Handler {
+	m := &middleware{
+		validator:  v,
+		responder:  DefaultErrorResponder,
+		skipRoutes: make(map[routeKey]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if m.skipper != nil && m.skipper(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			route, params, err := m.validator.findRoute(r)
+			if err != nil {
+				m.responder(w, r, err)
+				return
+			}
+
+			_, skip := m.skipRoutes[routeKey{method: route.Method, path: route.Path}]
+			if !skip {
+				if err := m.validator.validateRoute(r.Context(), r, route, params); err != nil {
+					m.responder(w, r, err)
+					return
+				}
+			}
+
+			ctx := context.WithValue(r.Context(), routeContextKey{}, &matchedRoute{route: route, params: decodePathParams(params)})
+			r = r.WithContext(ctx)
+			if skip || m.reporter == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			rec := newResponseRecorder()
+			next.ServeHTTP(rec, r)
+			rec.flush(w)
+			if err := m.validator.validateResponse(ctx, r, route, params, rec.status, rec.header, rec.body.Bytes()); err != nil {
+				m.reporter(r, rec.status, err)
+			}
+		})
+	}
+}
+
+// DefaultErrorResponder rejects the requests that can't be routed with a 404 or a 405, the
+// ones that don't meet the security requirements with a 401 or a 403 and every other invalid
+// request with a 400, writing the validation error as plain text.
+func DefaultErrorResponder(w http.ResponseWriter, r *http.Request, err error) {
+	status := http.StatusBadRequest
+	switch {
+	case errors.Is(err, routers.ErrPathNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, routers.ErrMethodNotAllowed):
+		status = http.StatusMethodNotAllowed
+	case IsForbidden(err):
+		status = http.StatusForbidden
+	case IsUnauthenticated(err):
+		status = http.StatusUnauthorized
+	}
+	http.Error(w, err.Error(), status)
+}
+
+// decodePathParams unescapes the path params, which the router matches on the encoded path.
+func decodePathParams(params map[string]string) map[string]string {
+	decoded := make(map[string]string, len(params))
+	for name, value := range params {
+		if unescaped, err := url.PathUnescape(value); err == nil {
+			value = unescaped
+		}
+		decoded[name] = value
+	}
+	return decoded
+}
+
+type routeContextKey struct{}
+
+type matchedRoute struct {
+	route  *routers.Route
+	params map[string]string
+}
+
+// RouteFromContext returns the route matched by the validation middleware.
+func RouteFromContext(ctx context.Context) (*routers.Route, bool) {
+	matched, ok := ctx.Value(routeContextKey{}).(*matchedRoute)
+	if !ok {
+		return nil, false
+	}
+	return matched.route, true
+}
+
+// PathParamsFromContext returns the decoded path params of the route matched by the
+// validation middleware.
+func PathParamsFromContext(ctx context.Context) (map[string]string, bool) {
+	matched, ok := ctx.Value(routeContextKey{}).(*matchedRoute)
+	if !ok {
+		return nil, false
+	}
+	return matched.params, true
+}
@@ -0,0 +1,115 @@
+package kinvalidator
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	api "request_validator/http/v1"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMiddleware(t *testing.T) {
+	// create the validator
+	ctx := context.Background()
+	swaggerDoc, err := api.GetSwagger()
+	require.NoError(t, err, "swagger recovery should not error")
+	validator := MustCreateValidator(ctx, swaggerDoc)
+
+	teapotResponder := func(w http.ResponseWriter, r *http.Request, err error) {
+		w.WriteHeader(http.StatusTeapot)
+	}
+
+	tests := []struct {
+		name       string
+		req        string
+		url        string
+		opts       []MiddlewareOption
+		wantStatus int
+		wantNext   bool
+	}{
+		{
+			name:       "given a valid request, when it goes through the middleware, it should be forwarded with its body and route",
+			req:        correctRequest,
+			url:        "http://api.example.com/v1/users/create",
+			wantStatus: http.StatusOK,
+			wantNext:   true,
+		},
+		{
+			name:       "given a request that does not have a required field specified, when it goes through the middleware, it should be rejected",
+			req:        missingMandatoryFieldRequest,
+			url:        "http://api.example.com/v1/users/create",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "given a request that does not come from one of the specified url server, when it goes through the middleware, it should be rejected",
+			req:        correctRequest,
+			url:        "http://unknown.example.com/v1/users/create",
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "given an invalid request and a custom error responder, when it goes through the middleware, the custom responder should reject it",
+			req:        invalidFormatFieldRequest,
+			url:        "http://api.example.com/v1/users/create",
+			opts:       []MiddlewareOption{WithErrorResponder(teapotResponder)},
+			wantStatus: http.StatusTeapot,
+		},
+		{
+			name:       "given an invalid request to a route that opted out, when it goes through the middleware, it should be forwarded with its body and route",
+			req:        invalidFormatFieldRequest,
+			url:        "http://api.example.com/v1/users/create",
+			opts:       []MiddlewareOption{WithSkipRoute(http.MethodPost, "/users/create")},
+			wantStatus: http.StatusOK,
+			wantNext:   true,
+		},
+		{
+			name: "given an invalid request that is skipped, when it goes through the middleware, it should be forwarded",
+			req:  invalidFormatFieldRequest,
+			url:  "http://unknown.example.com/v1/users/create",
+			opts: []MiddlewareOption{WithSkipper(func(r *http.Request) bool {
+				return r.URL.Host == "unknown.example.com"
+			})},
+			wantStatus: http.StatusNoContent,
+			wantNext:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, tt.url, bytes.NewReader([]byte(tt.req)))
+			require.NoError(t, err, "http request creation should not error")
+			httpRequest.Header.Add("Content-Type", "application/json")
+			recorder := httptest.NewRecorder()
+
+			nextCalled := false
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				nextCalled = true
+				route, ok := RouteFromContext(r.Context())
+				if !ok {
+					w.WriteHeader(http.StatusNoContent)
+					return
+				}
+				require.Equal(t, "/users/create", route.Path)
+				params, ok := PathParamsFromContext(r.Context())
+				require.True(t, ok, "path params should be in the context")
+				require.Empty(t, params)
+
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err, "body should be readable")
+				require.Equal(t, tt.req, string(body))
+				w.WriteHeader(http.StatusOK)
+			})
+
+			// act
+			validator.Middleware(tt.opts...)(next).ServeHTTP(recorder, httpRequest)
+
+			// assert
+			require.Equal(t, tt.wantStatus, recorder.Code)
+			require.Equal(t, tt.wantNext, nextCalled)
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package kinvalidator
+
+import (
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+)
+
+// RouterFactory creates the router that matches the requests with the operations of the document.
+type RouterFactory func(doc *openapi3.T) (routers.Router, error)
+
+// Option configures the validator created by NewValidator.
+type Option func(*options)
+
+type options struct {
+	authenticationFunc openapi3filter.AuthenticationFunc
+	authenticators     map[string]Authenticator
+	multiError         bool
+	excludeRequestBody bool
+	bodyDecoders       map[string]openapi3filter.BodyDecoder
+	formatValidators   map[string]openapi3.StringFormatValidator
+	servers            []string
+	routerFactory      RouterFactory
+}
+
+// WithAuthenticationFunc sets the function that checks the security requirements of the
+// operations. By default the security requirements are not checked, unless authenticators
+// are set with WithAuthenticator, in which case the function checks the schemes that don't
+// have one.
+func WithAuthenticationFunc(fn openapi3filter.AuthenticationFunc) Option {
+	return func(o *options) {
+		o.authenticationFunc = fn
+	}
+}
+
+// WithAuthenticator sets the authenticator of the security scheme of the document with the
+// given name. Once an authenticator is set, the requirements of the schemes without one are
+// rejected, unless a fallback is set with WithAuthenticationFunc.
+func WithAuthenticator(scheme string, authenticator Authenticator) Option {
+	return func(o *options) {
+		o.authenticators[scheme] = authenticator
+	}
+}
+
+// WithMultiError collects every violation of the request instead of stopping at the first one.
+// The violations are returned as an openapi3.MultiError.
+func WithMultiError() Option {
+	return func(o *options) {
+		o.multiError = true
+	}
+}
+
+// WithExcludeRequestBody leaves the request bodies unvalidated, so they can be validated by
+// another validator, while the server, the params and the security are still checked.
+func WithExcludeRequestBody() Option {
+	return func(o *options) {
+		o.excludeRequestBody = true
+	}
+}
+
+// WithBodyDecoder sets the decoder of the request bodies with the given content type. The
+// kin-openapi library keeps a single registry of body decoders per process, so the decoder
+// is used by every validator. It is registered once the validator is created, and
+// NewValidator returns an error for an empty content type or a nil decoder.
+func WithBodyDecoder(contentType string, decoder openapi3filter.BodyDecoder) Option {
+	return func(o *options) {
+		o.bodyDecoders[contentType] = decoder
+	}
+}
+
+// WithStringFormatValidator sets the validator of the string values with the given format,
+// replacing the one of the formats library if there is one. The format validators are scoped
+// to the validator, so validators of different documents can use different rules for the same
+// format. The byte, date and date-time formats that kin-openapi defines are neutralised when
+// this package is imported, so they are replaced too.
+func WithStringFormatValidator(format string, validator openapi3.StringFormatValidator) Option {
+	return func(o *options) {
+		o.formatValidators[format] = validator
+	}
+}
+
+// WithServers replaces the servers of the document used to match the origin server of the
+// requests, e.g. to accept the requests of a local environment.
+func WithServers(urls ...string) Option {
+	return func(o *options) {
+		o.servers = urls
+	}
+}
+
+// WithRouter sets the factory of the router that matches the requests with the operations of
+// the document. By default a gorilla/mux router is used.
+func WithRouter(factory RouterFactory) Option {
+	return func(o *options) {
+		o.routerFactory = factory
+	}
+}
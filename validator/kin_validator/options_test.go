@@ -0,0 +1,160 @@
+package kinvalidator
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	api "request_validator/http/v1"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/legacy"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewValidator(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		doc      func(t *testing.T) *openapi3.T
+		opts     []Option
+		req      string
+		url      string
+		wantFunc func(t *testing.T, newErr error, validateErr error)
+	}{
+		{
+			name: "given no document, when we create the validator, an error should be returned",
+			doc:  func(t *testing.T) *openapi3.T { return nil },
+			wantFunc: func(t *testing.T, newErr error, validateErr error) {
+				require.Error(t, newErr, "validator creation should error")
+			},
+		},
+		{
+			name: "given an invalid document, when we create the validator, an error should be returned instead of panicking",
+			doc:  func(t *testing.T) *openapi3.T { return &openapi3.T{OpenAPI: "3.0.0"} },
+			wantFunc: func(t *testing.T, newErr error, validateErr error) {
+				require.ErrorContains(t, newErr, "unable to validate open api specs")
+			},
+		},
+		{
+			name: "given a body decoder with an empty content type, when we create the validator, an error should be returned instead of panicking",
+			opts: []Option{WithBodyDecoder("", openapi3filter.RegisteredBodyDecoder("application/json"))},
+			wantFunc: func(t *testing.T, newErr error, validateErr error) {
+				require.ErrorContains(t, newErr, "empty content type")
+			},
+		},
+		{
+			name: "given a nil body decoder, when we create the validator, an error should be returned instead of panicking",
+			opts: []Option{WithBodyDecoder("application/x-test-nil", nil)},
+			wantFunc: func(t *testing.T, newErr error, validateErr error) {
+				require.ErrorContains(t, newErr, "nil decoder")
+			},
+		},
+		{
+			name: "given a body decoder and an invalid document, when we create the validator, the decoder should not be registered",
+			doc:  func(t *testing.T) *openapi3.T { return &openapi3.T{OpenAPI: "3.0.0"} },
+			opts: []Option{WithBodyDecoder("application/x-test-unregistered", openapi3filter.RegisteredBodyDecoder("application/json"))},
+			wantFunc: func(t *testing.T, newErr error, validateErr error) {
+				require.Error(t, newErr, "validator creation should error")
+				require.Nil(t, openapi3filter.RegisteredBodyDecoder("application/x-test-unregistered"), "the decoder should not be registered")
+			},
+		},
+		{
+			name: "given the multi error option, when we validate a request with several violations, all of them should be returned",
+			opts: []Option{WithMultiError()},
+			req:  `{"id": "sadwefsds", "firstName": "Jon"}`,
+			url:  "http://api.example.com/v1/users/create",
+			wantFunc: func(t *testing.T, newErr error, validateErr error) {
+				require.NoError(t, newErr, "validator creation should not error")
+				var multiErr openapi3.MultiError
+				require.True(t, errors.As(validateErr, &multiErr), "error should be of type MultiError")
+				require.Len(t, multiErr, 2)
+				require.ErrorContains(t, validateErr, `property "lastName" is missing`)
+				require.ErrorContains(t, validateErr, `string doesn't match the format "uuid"`)
+			},
+		},
+		{
+			name: "given custom servers, when we validate a request from one of them, no error should be returned",
+			opts: []Option{WithServers("http://localhost:8080/v1")},
+			req:  correctRequest,
+			url:  "http://localhost:8080/v1/users/create",
+			wantFunc: func(t *testing.T, newErr error, validateErr error) {
+				require.NoError(t, newErr, "validator creation should not error")
+				require.NoError(t, validateErr, "validator should not error")
+			},
+		},
+		{
+			name: "given custom servers, when we validate a request from a server of the document, an error should be returned",
+			opts: []Option{WithServers("http://localhost:8080/v1")},
+			req:  correctRequest,
+			url:  "http://api.example.com/v1/users/create",
+			wantFunc: func(t *testing.T, newErr error, validateErr error) {
+				require.NoError(t, newErr, "validator creation should not error")
+				require.True(t, errors.Is(validateErr, routers.ErrPathNotFound), "error should be of type ErrPathNotFound")
+			},
+		},
+		{
+			name: "given a custom router, when we validate a valid request, no error should be returned",
+			opts: []Option{WithRouter(func(doc *openapi3.T) (routers.Router, error) { return legacy.NewRouter(doc) })},
+			req:  correctRequest,
+			url:  "http://api.example.com/v1/users/create",
+			wantFunc: func(t *testing.T, newErr error, validateErr error) {
+				require.NoError(t, newErr, "validator creation should not error")
+				require.NoError(t, validateErr, "validator should not error")
+			},
+		},
+		{
+			name: "given an authentication function and a document with security requirements, when we validate a request, the function should reject it",
+			doc: func(t *testing.T) *openapi3.T {
+				doc, err := api.GetSwagger()
+				require.NoError(t, err, "swagger recovery should not error")
+				doc.Components.SecuritySchemes = openapi3.SecuritySchemes{
+					"apiKey": &openapi3.SecuritySchemeRef{Value: openapi3.NewSecurityScheme().WithType("apiKey").WithIn("header").WithName("X-API-Key")},
+				}
+				doc.Security = openapi3.SecurityRequirements{openapi3.NewSecurityRequirement().Authenticate("apiKey")}
+				return doc
+			},
+			opts: []Option{WithAuthenticationFunc(func(ctx context.Context, input *openapi3filter.AuthenticationInput) error {
+				return errors.New("missing api key")
+			})},
+			req: correctRequest,
+			url: "http://api.example.com/v1/users/create",
+			wantFunc: func(t *testing.T, newErr error, validateErr error) {
+				require.NoError(t, newErr, "validator creation should not error")
+				var securityErr *openapi3filter.SecurityRequirementsError
+				require.True(t, errors.As(validateErr, &securityErr), "error should be of type SecurityRequirementsError")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			var doc *openapi3.T
+			if tt.doc != nil {
+				doc = tt.doc(t)
+			} else {
+				var err error
+				doc, err = api.GetSwagger()
+				require.NoError(t, err, "swagger recovery should not error")
+			}
+
+			// act
+			validator, newErr := NewValidator(ctx, doc, tt.opts...)
+			var validateErr error
+			if newErr == nil {
+				httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, tt.url, bytes.NewReader([]byte(tt.req)))
+				require.NoError(t, err, "http request creation should not error")
+				httpRequest.Header.Add("Content-Type", "application/json")
+				validateErr = validator.ValidateRequest(ctx, httpRequest)
+			}
+
+			// assert
+			tt.wantFunc(t, newErr, validateErr)
+		})
+	}
+}
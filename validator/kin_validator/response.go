@@ -0,0 +1,110 @@
+package kinvalidator
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+)
+
+// ValidateResponse checks if the response to the request follows the responses of its
+// operation in the document: the status code must be declared, either explicitly or with
+// a default response, and the headers and the body must match their schemas.
+func (v *Validator) ValidateResponse(ctx context.Context, httpRq *http.Request, status int, header http.Header, body []byte) error {
+	r, params, err := v.findRoute(httpRq)
+	if err != nil {
+		return err
+	}
+	return v.validateResponse(ctx, httpRq, r, params, status, header, body)
+}
+
+func (v *Validator) validateResponse(ctx context.Context, httpRq *http.Request, r *routers.Route, params map[string]string, status int, header http.Header, body []byte) error {
+	options := *v.options
+	options.IncludeResponseStatus = true
+
+	responseValidationInput := &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: &openapi3filter.RequestValidationInput{
+			Request:    httpRq,
+			PathParams: params,
+			Route:      r,
+			Options:    &options,
+		},
+		Status:  status,
+		Header:  header,
+		Body:    io.NopCloser(bytes.NewReader(body)),
+		Options: &options,
+	}
+	bodyDecodersMu.RLock()
+	err := openapi3filter.ValidateResponse(ctx, responseValidationInput)
+	bodyDecodersMu.RUnlock()
+	if err == nil || options.MultiError {
+		err = appendErrors(err, errorList(v.formats.validateResponse(responseValidationInput, body)))
+	}
+	if err != nil {
+		return fmt.Errorf("error validating response: %w", err)
+	}
+	return nil
+}
+
+func errorList(err error) []error {
+	if err == nil {
+		return nil
+	}
+	return []error{err}
+}
+
+// ResponseReporter is notified of the responses that don't follow the document. The
+// response has already been written to the client when it is called.
+type ResponseReporter func(r *http.Request, status int, err error)
+
+// LogResponseReporter writes the response validation errors to the standard logger.
+func LogResponseReporter(r *http.Request, status int, err error) {
+	log.Printf("invalid %d response to %s %s: %v", status, r.Method, r.URL.Path, err)
+}
+
+// responseRecorder buffers the response of the next handler so it can be validated.
+type responseRecorder struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func newResponseRecorder() *responseRecorder {
+	return &responseRecorder{header: make(http.Header)}
+}
+
+func (rec *responseRecorder) Header() http.Header {
+	return rec.header
+}
+
+func (rec *responseRecorder) WriteHeader(status int) {
+	if rec.status == 0 {
+		rec.status = status
+	}
+}
+
+func (rec *responseRecorder) Write(p []byte) (int, error) {
+	rec.WriteHeader(http.StatusOK)
+	return rec.body.Write(p)
+}
+
+// flush writes the buffered response, detecting its content type like the http server does
+// when the handler didn't set one.
+func (rec *responseRecorder) flush(w http.ResponseWriter) {
+	rec.WriteHeader(http.StatusOK)
+	if _, ok := rec.header["Content-Type"]; !ok && rec.body.Len() > 0 {
+		rec.header.Set("Content-Type", http.DetectContentType(rec.body.Bytes()))
+	}
+
+	header := w.Header()
+	for name, values := range rec.header {
+		header[name] = values
+	}
+	w.WriteHeader(rec.status)
+	_, _ = w.Write(rec.body.Bytes())
+}
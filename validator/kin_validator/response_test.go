@@ -0,0 +1,226 @@
+package kinvalidator
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/stretchr/testify/require"
+)
+
+const usersSpec = `
+openapi: 3.0.0
+info:
+  title: Users API
+  version: 0.1.0
+servers:
+  - url: http://api.example.com/v1
+paths:
+  /users/{id}:
+    get:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        '200':
+          description: The user
+          headers:
+            X-Rate-Limit:
+              required: true
+              schema:
+                type: integer
+          content:
+            application/json:
+              schema:
+                type: object
+                required: [id, firstName]
+                properties:
+                  id:
+                    type: string
+                    format: uuid
+                  firstName:
+                    type: string
+                  email:
+                    type: string
+                    format: email
+        '404':
+          description: The user does not exist
+`
+
+const userResponse = `{"id": "32d3e8f1-2f81-49c0-acb6-6dccd84f3dab", "firstName": "Jon", "email": "jon_snow@winterfell.com"}`
+
+func TestValidateResponse(t *testing.T) {
+	ctx := context.Background()
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(usersSpec))
+	require.NoError(t, err, "spec loading should not error")
+	validator, err := NewValidator(ctx, doc)
+	require.NoError(t, err, "validator creation should not error")
+
+	jsonHeader := func(rateLimit string) http.Header {
+		header := http.Header{"Content-Type": []string{"application/json"}}
+		if rateLimit != "" {
+			header.Set("X-Rate-Limit", rateLimit)
+		}
+		return header
+	}
+
+	tests := []struct {
+		name     string
+		status   int
+		header   http.Header
+		body     string
+		wantFunc func(t *testing.T, err error)
+	}{
+		{
+			name:     "given a response that follows its operation, when we try to validate it, no error should be returned",
+			status:   http.StatusOK,
+			header:   jsonHeader("100"),
+			body:     userResponse,
+			wantFunc: func(t *testing.T, err error) { require.NoError(t, err, "validator should not error") },
+		},
+		{
+			name:     "given a declared response without content, when we try to validate it, no error should be returned",
+			status:   http.StatusNotFound,
+			header:   http.Header{},
+			wantFunc: func(t *testing.T, err error) { require.NoError(t, err, "validator should not error") },
+		},
+		{
+			name:   "given a response with a status code that is not declared, when we try to validate it, an error should be returned",
+			status: http.StatusInternalServerError,
+			header: http.Header{},
+			wantFunc: func(t *testing.T, err error) {
+				var responseErr *openapi3filter.ResponseError
+				require.True(t, errors.As(err, &responseErr), "error should be of type ResponseError")
+				require.Equal(t, "status is not supported", responseErr.Reason)
+			},
+		},
+		{
+			name:   "given a response without a required header, when we try to validate it, an error should be returned",
+			status: http.StatusOK,
+			header: jsonHeader(""),
+			body:   userResponse,
+			wantFunc: func(t *testing.T, err error) {
+				var responseErr *openapi3filter.ResponseError
+				require.True(t, errors.As(err, &responseErr), "error should be of type ResponseError")
+				require.Contains(t, responseErr.Reason, "X-Rate-Limit")
+			},
+		},
+		{
+			name:   "given a response whose body does not have a required field, when we try to validate it, an error should be returned",
+			status: http.StatusOK,
+			header: jsonHeader("100"),
+			body:   `{"id": "32d3e8f1-2f81-49c0-acb6-6dccd84f3dab"}`,
+			wantFunc: func(t *testing.T, err error) {
+				var schemaErr *openapi3.SchemaError
+				require.True(t, errors.As(err, &schemaErr), "error should be of type SchemaError")
+			},
+		},
+		{
+			name:   "given a response whose body has an invalid email format, when we try to validate it, a format error should be returned",
+			status: http.StatusOK,
+			header: jsonHeader("100"),
+			body:   `{"id": "32d3e8f1-2f81-49c0-acb6-6dccd84f3dab", "firstName": "Jon", "email": "this_is_a_test"}`,
+			wantFunc: func(t *testing.T, err error) {
+				var formatErr *FormatError
+				require.True(t, errors.As(err, &formatErr), "error should be of type FormatError")
+				require.Equal(t, []string{"email"}, formatErr.JSONPointer())
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			httpRequest, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://api.example.com/v1/users/32d3e8f1-2f81-49c0-acb6-6dccd84f3dab", nil)
+			require.NoError(t, err, "http request creation should not error")
+
+			// act
+			err = validator.ValidateResponse(ctx, httpRequest, tt.status, tt.header, []byte(tt.body))
+
+			// assert
+			tt.wantFunc(t, err)
+		})
+	}
+}
+
+func TestResponseValidationMiddleware(t *testing.T) {
+	ctx := context.Background()
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(usersSpec))
+	require.NoError(t, err, "spec loading should not error")
+	validator, err := NewValidator(ctx, doc)
+	require.NoError(t, err, "validator creation should not error")
+
+	tests := []struct {
+		name       string
+		rateLimit  string
+		body       string
+		opts       func(reporter ResponseReporter) []MiddlewareOption
+		wantReport bool
+	}{
+		{
+			name:      "given a handler that writes a valid response, when it goes through the middleware, it should not be reported",
+			rateLimit: "100",
+			body:      userResponse,
+			opts: func(reporter ResponseReporter) []MiddlewareOption {
+				return []MiddlewareOption{WithResponseValidation(reporter)}
+			},
+		},
+		{
+			name: "given a handler that writes an invalid response, when it goes through the middleware, it should be reported and written unchanged",
+			body: `{"id": "sadwefsds", "firstName": "Jon"}`,
+			opts: func(reporter ResponseReporter) []MiddlewareOption {
+				return []MiddlewareOption{WithResponseValidation(reporter)}
+			},
+			wantReport: true,
+		},
+		{
+			name: "given a handler of a skipped route that writes an invalid response, when it goes through the middleware, it should not be reported",
+			body: `{"id": "sadwefsds", "firstName": "Jon"}`,
+			opts: func(reporter ResponseReporter) []MiddlewareOption {
+				return []MiddlewareOption{WithResponseValidation(reporter), WithSkipRoute(http.MethodGet, "/users/{id}")}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			httpRequest, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://api.example.com/v1/users/32d3e8f1-2f81-49c0-acb6-6dccd84f3dab", nil)
+			require.NoError(t, err, "http request creation should not error")
+			recorder := httptest.NewRecorder()
+
+			var reported error
+			reporter := func(r *http.Request, status int, err error) {
+				require.Equal(t, http.StatusOK, status)
+				reported = err
+			}
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				if tt.rateLimit != "" {
+					w.Header().Set("X-Rate-Limit", tt.rateLimit)
+				}
+				_, _ = w.Write([]byte(tt.body))
+			})
+
+			// act
+			validator.Middleware(tt.opts(reporter)...)(next).ServeHTTP(recorder, httpRequest)
+
+			// assert
+			require.Equal(t, http.StatusOK, recorder.Code)
+			require.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+			require.Equal(t, tt.body, recorder.Body.String())
+			if tt.wantReport {
+				require.Error(t, reported, "the invalid response should be reported")
+			} else {
+				require.NoError(t, reported, "the response should not be reported")
+			}
+		})
+	}
+}
@@ -0,0 +1,196 @@
+package kinvalidator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+)
+
+var (
+	// ErrUnauthenticated is matched by the security errors of the requests whose credentials
+	// are missing or not valid, which should be rejected with a 401.
+	ErrUnauthenticated = errors.New("unauthenticated")
+	// ErrForbidden is matched by the security errors of the requests whose credentials are
+	// valid but lack the required scopes, which should be rejected with a 403.
+	ErrForbidden = errors.New("forbidden")
+)
+
+// SecurityError is returned by the authenticators when a request doesn't meet one of the
+// security schemes of the document. It matches either ErrUnauthenticated or ErrForbidden.
+type SecurityError struct {
+	// Scheme is the name of the security scheme in the document.
+	Scheme string
+	// Forbidden is set when the credentials are valid but lack the required scopes.
+	Forbidden bool
+	Err       error
+}
+
+func (e *SecurityError) Error() string {
+	return fmt.Sprintf("security scheme %q: %v", e.Scheme, e.Err)
+}
+
+func (e *SecurityError) Unwrap() error {
+	return e.Err
+}
+
+func (e *SecurityError) Is(target error) bool {
+	if e.Forbidden {
+		return target == ErrForbidden
+	}
+	return target == ErrUnauthenticated
+}
+
+// IsForbidden reports whether the request failed the security requirements because its
+// credentials lack the required scopes. When the operation accepts several requirements,
+// a single forbidden one is enough, since the client did authenticate.
+func IsForbidden(err error) bool {
+	return errors.Is(err, ErrForbidden)
+}
+
+// IsUnauthenticated reports whether the request failed the security requirements of the
+// operation, including the errors of custom authentication functions.
+func IsUnauthenticated(err error) bool {
+	var securityErr *openapi3filter.SecurityRequirementsError
+	return errors.Is(err, ErrUnauthenticated) || errors.As(err, &securityErr)
+}
+
+func unauthenticated(input *openapi3filter.AuthenticationInput, err error) error {
+	return &SecurityError{Scheme: input.SecuritySchemeName, Err: err}
+}
+
+// Authenticator checks the credentials of a request against one of the security schemes of
+// the document. It should return a SecurityError if they don't meet the scheme.
+type Authenticator func(ctx context.Context, input *openapi3filter.AuthenticationInput) error
+
+// newAuthenticationFunc dispatches the security requirements to the authenticator of their
+// scheme. The schemes without an authenticator use the fallback function, if there is one,
+// and are rejected otherwise.
+func newAuthenticationFunc(authenticators map[string]Authenticator, fallback openapi3filter.AuthenticationFunc) openapi3filter.AuthenticationFunc {
+	if len(authenticators) == 0 {
+		if fallback == nil {
+			return openapi3filter.NoopAuthenticationFunc
+		}
+		return fallback
+	}
+
+	return func(ctx context.Context, input *openapi3filter.AuthenticationInput) error {
+		if authenticate, ok := authenticators[input.SecuritySchemeName]; ok {
+			return authenticate(ctx, input)
+		}
+		if fallback != nil {
+			return fallback(ctx, input)
+		}
+		return unauthenticated(input, errors.New("no authenticator is set for the scheme"))
+	}
+}
+
+// APIKeyAuthenticator returns an authenticator of the apiKey schemes, which reads the key from
+// the header, query param or cookie of the scheme and accepts it if check doesn't error.
+func APIKeyAuthenticator(check func(ctx context.Context, key string) error) Authenticator {
+	return func(ctx context.Context, input *openapi3filter.AuthenticationInput) error {
+		scheme := input.SecurityScheme
+		if scheme.Type != "apiKey" {
+			return unauthenticated(input, fmt.Errorf("the scheme of type %q is not an API key scheme", scheme.Type))
+		}
+
+		r := input.RequestValidationInput.Request
+		var key string
+		switch scheme.In {
+		case openapi3.ParameterInHeader:
+			key = r.Header.Get(scheme.Name)
+		case openapi3.ParameterInQuery:
+			key = r.URL.Query().Get(scheme.Name)
+		case openapi3.ParameterInCookie:
+			if c, err := r.Cookie(scheme.Name); err == nil {
+				key = c.Value
+			}
+		}
+		if key == "" {
+			return unauthenticated(input, fmt.Errorf("missing API key in %s %q", scheme.In, scheme.Name))
+		}
+
+		if err := check(ctx, key); err != nil {
+			return unauthenticated(input, err)
+		}
+		return nil
+	}
+}
+
+// BasicAuthenticator returns an authenticator of the http basic schemes, which accepts the
+// credentials of the Authorization header if check doesn't error.
+func BasicAuthenticator(check func(ctx context.Context, username, password string) error) Authenticator {
+	return func(ctx context.Context, input *openapi3filter.AuthenticationInput) error {
+		scheme := input.SecurityScheme
+		if scheme.Type != "http" || !strings.EqualFold(scheme.Scheme, "basic") {
+			return unauthenticated(input, errors.New("the scheme is not an http basic scheme"))
+		}
+
+		username, password, ok := input.RequestValidationInput.Request.BasicAuth()
+		if !ok {
+			return unauthenticated(input, errors.New("missing basic credentials"))
+		}
+
+		if err := check(ctx, username, password); err != nil {
+			return unauthenticated(input, err)
+		}
+		return nil
+	}
+}
+
+// BearerAuthenticator returns an authenticator of the http bearer, oauth2 and openIdConnect
+// schemes, which reads the token of the Authorization header. The token is accepted if check
+// doesn't error and returns every scope required by the operation.
+func BearerAuthenticator(check func(ctx context.Context, token string) (scopes []string, err error)) Authenticator {
+	return func(ctx context.Context, input *openapi3filter.AuthenticationInput) error {
+		scheme := input.SecurityScheme
+		switch {
+		case scheme.Type == "http" && strings.EqualFold(scheme.Scheme, "bearer"):
+		case scheme.Type == "oauth2", scheme.Type == "openIdConnect":
+		default:
+			return unauthenticated(input, errors.New("the scheme is not a bearer token scheme"))
+		}
+
+		token, ok := bearerToken(input.RequestValidationInput.Request)
+		if !ok {
+			return unauthenticated(input, errors.New("missing bearer token"))
+		}
+
+		scopes, err := check(ctx, token)
+		if err != nil {
+			return unauthenticated(input, err)
+		}
+
+		granted := make(map[string]struct{}, len(scopes))
+		for _, scope := range scopes {
+			granted[scope] = struct{}{}
+		}
+		var missing []string
+		for _, scope := range input.Scopes {
+			if _, ok := granted[scope]; !ok {
+				missing = append(missing, scope)
+			}
+		}
+		if len(missing) > 0 {
+			return &SecurityError{
+				Scheme:    input.SecuritySchemeName,
+				Forbidden: true,
+				Err:       fmt.Errorf("the token lacks the scopes %q", missing),
+			}
+		}
+		return nil
+	}
+}
+
+func bearerToken(r *http.Request) (string, bool) {
+	const prefix = "bearer "
+	header := r.Header.Get("Authorization")
+	if len(header) <= len(prefix) || !strings.EqualFold(header[:len(prefix)], prefix) {
+		return "", false
+	}
+	return strings.TrimSpace(header[len(prefix):]), true
+}
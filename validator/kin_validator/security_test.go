@@ -0,0 +1,334 @@
+package kinvalidator
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/require"
+)
+
+const securedSpec = `
+openapi: 3.0.0
+info:
+  title: Secured API
+  version: 0.1.0
+servers:
+  - url: http://api.example.com/v1
+components:
+  securitySchemes:
+    apiKeyHeader:
+      type: apiKey
+      in: header
+      name: X-API-Key
+    apiKeyQuery:
+      type: apiKey
+      in: query
+      name: api_key
+    basicAuth:
+      type: http
+      scheme: basic
+    bearerAuth:
+      type: http
+      scheme: bearer
+      bearerFormat: JWT
+    oauth:
+      type: oauth2
+      flows:
+        clientCredentials:
+          tokenUrl: http://auth.example.com/token
+          scopes:
+            users:read: read the users
+            users:write: write the users
+paths:
+  /keys:
+    get:
+      security:
+        - apiKeyHeader: []
+        - apiKeyQuery: []
+      responses:
+        '200':
+          description: The request was authenticated
+  /basic:
+    get:
+      security:
+        - basicAuth: []
+      responses:
+        '200':
+          description: The request was authenticated
+  /bearer:
+    get:
+      security:
+        - bearerAuth: []
+      responses:
+        '200':
+          description: The request was authenticated
+  /users:
+    post:
+      security:
+        - oauth: [users:write]
+      responses:
+        '200':
+          description: The request was authenticated
+`
+
+var secret = []byte("winter-is-coming")
+
+// signToken creates a JWT with the given claims, signed with the given algorithm and key.
+func signToken(t *testing.T, alg, kid string, key interface{}, claims map[string]interface{}) string {
+	header := map[string]string{"alg": alg, "typ": "JWT"}
+	if kid != "" {
+		header["kid"] = kid
+	}
+	encode := func(v interface{}) string {
+		data, err := json.Marshal(v)
+		require.NoError(t, err, "token segment should be marshalled")
+		return base64.RawURLEncoding.EncodeToString(data)
+	}
+	signed := encode(header) + "." + encode(claims)
+
+	var signature []byte
+	switch k := key.(type) {
+	case []byte:
+		mac := hmac.New(sha256.New, k)
+		mac.Write([]byte(signed))
+		signature = mac.Sum(nil)
+	case *ecdsa.PrivateKey:
+		digest := sha256.Sum256([]byte(signed))
+		r, s, err := ecdsa.Sign(rand.Reader, k, digest[:])
+		require.NoError(t, err, "token should be signed")
+		signature = append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	case ed25519.PrivateKey:
+		signature = ed25519.Sign(k, []byte(signed))
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func TestSecurity(t *testing.T) {
+	ctx := context.Background()
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(securedSpec))
+	require.NoError(t, err, "spec loading should not error")
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err, "ecdsa key generation should not error")
+	edPublicKey, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err, "ed25519 key generation should not error")
+
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	jwt := JWTAuthenticator(
+		WithJWTKey("hs", secret),
+		WithJWTKey("es", &ecKey.PublicKey),
+		WithJWTKey("ed", edPublicKey),
+		WithJWTIssuer("http://auth.example.com"),
+		WithJWTClock(func() time.Time { return now }),
+	)
+	validator, err := NewValidator(ctx, doc,
+		WithAuthenticator("apiKeyHeader", APIKeyAuthenticator(func(ctx context.Context, key string) error {
+			if key != "stark" {
+				return errors.New("unknown API key")
+			}
+			return nil
+		})),
+		WithAuthenticator("apiKeyQuery", APIKeyAuthenticator(func(ctx context.Context, key string) error {
+			if key != "lannister" {
+				return errors.New("unknown API key")
+			}
+			return nil
+		})),
+		WithAuthenticator("basicAuth", BasicAuthenticator(func(ctx context.Context, username, password string) error {
+			if username != "jon" || password != "ghost" {
+				return errors.New("wrong username or password")
+			}
+			return nil
+		})),
+		WithAuthenticator("bearerAuth", jwt),
+		WithAuthenticator("oauth", jwt),
+	)
+	require.NoError(t, err, "validator creation should not error")
+
+	claims := func(scope string, expiresIn time.Duration) map[string]interface{} {
+		return map[string]interface{}{
+			"iss":   "http://auth.example.com",
+			"sub":   "jon",
+			"scope": scope,
+			"exp":   now.Add(expiresIn).Unix(),
+		}
+	}
+	bearer := func(token string) http.Header {
+		return http.Header{"Authorization": []string{"Bearer " + token}}
+	}
+
+	tests := []struct {
+		name     string
+		method   string
+		url      string
+		header   http.Header
+		wantFunc func(t *testing.T, err error)
+	}{
+		{
+			name:     "given a request with a valid API key in the header, when we try to validate it, no error should be returned",
+			url:      "http://api.example.com/v1/keys",
+			header:   http.Header{"X-Api-Key": []string{"stark"}},
+			wantFunc: func(t *testing.T, err error) { require.NoError(t, err, "validator should not error") },
+		},
+		{
+			name:     "given a request with a valid API key in the query, when we try to validate it, no error should be returned",
+			url:      "http://api.example.com/v1/keys?api_key=lannister",
+			wantFunc: func(t *testing.T, err error) { require.NoError(t, err, "validator should not error") },
+		},
+		{
+			name:   "given a request with an unknown API key, when we try to validate it, an unauthenticated error should be returned",
+			url:    "http://api.example.com/v1/keys",
+			header: http.Header{"X-Api-Key": []string{"lannister"}},
+			wantFunc: func(t *testing.T, err error) {
+				require.True(t, IsUnauthenticated(err), "error should be unauthenticated")
+				require.False(t, IsForbidden(err), "error should not be forbidden")
+			},
+		},
+		{
+			name:     "given a request with valid basic credentials, when we try to validate it, no error should be returned",
+			url:      "http://api.example.com/v1/basic",
+			header:   http.Header{"Authorization": []string{"Basic " + base64.StdEncoding.EncodeToString([]byte("jon:ghost"))}},
+			wantFunc: func(t *testing.T, err error) { require.NoError(t, err, "validator should not error") },
+		},
+		{
+			name:   "given a request with wrong basic credentials, when we try to validate it, an unauthenticated error should be returned",
+			url:    "http://api.example.com/v1/basic",
+			header: http.Header{"Authorization": []string{"Basic " + base64.StdEncoding.EncodeToString([]byte("jon:nymeria"))}},
+			wantFunc: func(t *testing.T, err error) {
+				var securityErr *SecurityError
+				require.True(t, errors.As(err, &securityErr), "error should be of type SecurityError")
+				require.Equal(t, "basicAuth", securityErr.Scheme)
+				require.True(t, errors.Is(err, ErrUnauthenticated), "error should be unauthenticated")
+			},
+		},
+		{
+			name:     "given a request with a JWT signed with a shared secret, when we try to validate it, no error should be returned",
+			url:      "http://api.example.com/v1/bearer",
+			header:   bearer(signToken(t, "HS256", "hs", secret, claims("", time.Hour))),
+			wantFunc: func(t *testing.T, err error) { require.NoError(t, err, "validator should not error") },
+		},
+		{
+			name:     "given a request with a JWT signed with an ECDSA key, when we try to validate it, no error should be returned",
+			url:      "http://api.example.com/v1/bearer",
+			header:   bearer(signToken(t, "ES256", "es", ecKey, claims("", time.Hour))),
+			wantFunc: func(t *testing.T, err error) { require.NoError(t, err, "validator should not error") },
+		},
+		{
+			name:     "given a request with a JWT signed with an Ed25519 key and no key id, when we try to validate it, no error should be returned",
+			url:      "http://api.example.com/v1/bearer",
+			header:   bearer(signToken(t, "EdDSA", "", edKey, claims("", time.Hour))),
+			wantFunc: func(t *testing.T, err error) { require.NoError(t, err, "validator should not error") },
+		},
+		{
+			name:   "given a request with a JWT signed with an unknown key, when we try to validate it, an unauthenticated error should be returned",
+			url:    "http://api.example.com/v1/bearer",
+			header: bearer(signToken(t, "HS256", "hs", []byte("you-know-nothing"), claims("", time.Hour))),
+			wantFunc: func(t *testing.T, err error) {
+				require.True(t, errors.Is(err, ErrUnauthenticated), "error should be unauthenticated")
+				require.Contains(t, err.Error(), "signature is not valid")
+			},
+		},
+		{
+			name:   "given a request with an unsigned JWT, when we try to validate it, an unauthenticated error should be returned",
+			url:    "http://api.example.com/v1/bearer",
+			header: bearer(signToken(t, "none", "", nil, claims("", time.Hour))),
+			wantFunc: func(t *testing.T, err error) {
+				require.True(t, errors.Is(err, ErrUnauthenticated), "error should be unauthenticated")
+			},
+		},
+		{
+			name:   "given a request with an expired JWT, when we try to validate it, an unauthenticated error should be returned",
+			url:    "http://api.example.com/v1/bearer",
+			header: bearer(signToken(t, "HS256", "hs", secret, claims("", -time.Minute))),
+			wantFunc: func(t *testing.T, err error) {
+				require.True(t, errors.Is(err, ErrUnauthenticated), "error should be unauthenticated")
+				require.Contains(t, err.Error(), "expired")
+			},
+		},
+		{
+			name:   "given a request without a bearer token, when we try to validate it, an unauthenticated error should be returned",
+			url:    "http://api.example.com/v1/bearer",
+			header: http.Header{},
+			wantFunc: func(t *testing.T, err error) {
+				require.True(t, errors.Is(err, ErrUnauthenticated), "error should be unauthenticated")
+			},
+		},
+		{
+			name:     "given a request with a JWT with the required scopes, when we try to validate it, no error should be returned",
+			method:   http.MethodPost,
+			url:      "http://api.example.com/v1/users",
+			header:   bearer(signToken(t, "HS256", "hs", secret, claims("users:read users:write", time.Hour))),
+			wantFunc: func(t *testing.T, err error) { require.NoError(t, err, "validator should not error") },
+		},
+		{
+			name:   "given a request with a JWT without the required scopes, when we try to validate it, a forbidden error should be returned",
+			method: http.MethodPost,
+			url:    "http://api.example.com/v1/users",
+			header: bearer(signToken(t, "HS256", "hs", secret, claims("users:read", time.Hour))),
+			wantFunc: func(t *testing.T, err error) {
+				require.True(t, IsForbidden(err), "error should be forbidden")
+				require.Contains(t, err.Error(), "users:write")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			method := tt.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			httpRequest, err := http.NewRequestWithContext(ctx, method, tt.url, nil)
+			require.NoError(t, err, "http request creation should not error")
+			for name, values := range tt.header {
+				httpRequest.Header[name] = values
+			}
+
+			// act
+			err = validator.ValidateRequest(ctx, httpRequest)
+
+			// assert
+			tt.wantFunc(t, err)
+		})
+	}
+}
+
+func TestSecurityWithoutAuthenticator(t *testing.T) {
+	ctx := context.Background()
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(securedSpec))
+	require.NoError(t, err, "spec loading should not error")
+	validator, err := NewValidator(ctx, doc, WithAuthenticator("apiKeyHeader", APIKeyAuthenticator(func(ctx context.Context, key string) error {
+		return nil
+	})))
+	require.NoError(t, err, "validator creation should not error")
+
+	// arrange
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://api.example.com/v1/basic", nil)
+	require.NoError(t, err, "http request creation should not error")
+	httpRequest.SetBasicAuth("jon", "ghost")
+	recorder := httptest.NewRecorder()
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	// act
+	validator.Middleware()(next).ServeHTTP(recorder, httpRequest)
+
+	// assert
+	require.Equal(t, http.StatusUnauthorized, recorder.Code)
+	require.Contains(t, recorder.Body.String(), "no authenticator")
+}
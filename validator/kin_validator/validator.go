@@ -2,62 +2,180 @@ package kinvalidator
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"strings"
+	"sync"
 
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/getkin/kin-openapi/openapi3filter"
 	"github.com/getkin/kin-openapi/routers"
 	"github.com/getkin/kin-openapi/routers/gorillamux"
-)
 
-var (
-	emailRegex = `^[a-z0-9._%+\-]+@[a-z0-9.\-]+\.[a-z]{2,4}$`
+	"request_validator/validator/formats"
 )
 
 type Validator struct {
-	router routers.Router
+	router  routers.Router
+	options *openapi3filter.Options
+	formats *formatRegistry
 }
 
+// MustCreateValidator creates a validator with the default options and panics if the
+// document is not valid.
 func MustCreateValidator(ctx context.Context, doc *openapi3.T) *Validator {
+	v, err := NewValidator(ctx, doc)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// NewValidator creates a validator of the requests against the given document.
+func NewValidator(ctx context.Context, doc *openapi3.T, opts ...Option) (*Validator, error) {
+	o := &options{
+		authenticators:   make(map[string]Authenticator),
+		bodyDecoders:     make(map[string]openapi3filter.BodyDecoder),
+		formatValidators: make(map[string]openapi3.StringFormatValidator),
+		routerFactory:    gorillamux.NewRouter,
+	}
+	// Validate the string formats the same way the go-playground validator does
+	for name, format := range formats.All() {
+		o.formatValidators[name] = format
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
 
-	// Set specific validation format for UUID and Email format typed fields
-	openapi3.DefineStringFormatValidator("uuid", openapi3.NewRegexpFormatValidator(openapi3.FormatOfStringForUUIDOfRFC4122))
-	openapi3.DefineStringFormatValidator("email", openapi3.NewRegexpFormatValidator(emailRegex))
+	for contentType, decoder := range o.bodyDecoders {
+		if strings.TrimSpace(contentType) == "" {
+			return nil, errors.New("unable to set the body decoder: empty content type")
+		}
+		if decoder == nil {
+			return nil, fmt.Errorf("unable to set the body decoder of %q: nil decoder", contentType)
+		}
+	}
 
+	if doc == nil {
+		return nil, errors.New("unable to validate open api specs: missing document")
+	}
 	err := doc.Validate(ctx)
 	if err != nil {
-		panic(fmt.Errorf("unable to validate open api specs: %w", err))
+		return nil, fmt.Errorf("unable to validate open api specs: %w", err)
 	}
 
-	router, err := gorillamux.NewRouter(doc)
+	if o.servers != nil {
+		// route against a shallow copy so the document of the caller is left untouched
+		routed := *doc
+		routed.Servers = make(openapi3.Servers, 0, len(o.servers))
+		for _, serverURL := range o.servers {
+			routed.Servers = append(routed.Servers, &openapi3.Server{URL: serverURL})
+		}
+		doc = &routed
+	}
+
+	router, err := o.routerFactory(doc)
 	if err != nil {
-		panic(fmt.Errorf("unable to create router: %w", err))
+		return nil, fmt.Errorf("unable to create router: %w", err)
 	}
 
+	// the decoders are only registered once the validator can be created
+	registerBodyDecoders(o.bodyDecoders)
+
 	return &Validator{
 		router: router,
-	}
+		options: &openapi3filter.Options{
+			AuthenticationFunc: newAuthenticationFunc(o.authenticators, o.authenticationFunc),
+			MultiError:         o.multiError,
+			ExcludeRequestBody: o.excludeRequestBody,
+		},
+		formats: newFormatRegistry(o.formatValidators, o.multiError),
+	}, nil
 }
 
 func (v *Validator) ValidateRequest(ctx context.Context, httpRq *http.Request) error {
 
+	r, params, err := v.findRoute(httpRq)
+	if err != nil {
+		return err
+	}
+	return v.validateRoute(ctx, httpRq, r, params)
+}
+
+// ValidateRoute validates the request like ValidateRequest and returns the route of the
+// operation it matched, so the caller can validate the parts excluded from the validation.
+func (v *Validator) ValidateRoute(ctx context.Context, httpRq *http.Request) (*routers.Route, error) {
+	r, params, err := v.findRoute(httpRq)
+	if err != nil {
+		return nil, err
+	}
+	if err := v.validateRoute(ctx, httpRq, r, params); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (v *Validator) findRoute(httpRq *http.Request) (*routers.Route, map[string]string, error) {
 	r, params, err := v.router.FindRoute(httpRq)
 	if err != nil {
-		return fmt.Errorf("error finding request route: %w", err)
+		return nil, nil, fmt.Errorf("error finding request route: %w", err)
 	}
+	return r, params, nil
+}
 
+func (v *Validator) validateRoute(ctx context.Context, httpRq *http.Request, r *routers.Route, params map[string]string) error {
 	requestValidationInput := &openapi3filter.RequestValidationInput{
 		Request:    httpRq,
 		PathParams: params,
 		Route:      r,
-		Options: &openapi3filter.Options{
-			AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
-		},
+		Options:    v.options,
+	}
+	bodyDecodersMu.RLock()
+	err := openapi3filter.ValidateRequest(ctx, requestValidationInput)
+	bodyDecodersMu.RUnlock()
+	if err == nil || v.options.MultiError {
+		err = appendErrors(err, v.formats.validateRequest(requestValidationInput))
 	}
-	err = openapi3filter.ValidateRequest(ctx, requestValidationInput)
 	if err != nil {
 		return fmt.Errorf("error validating request: %w", err)
 	}
 	return nil
 }
+
+// bodyDecodersMu guards the registry of body decoders of kin-openapi, which is global to the
+// process, against the validations of the requests while a validator registers its decoders.
+var bodyDecodersMu sync.RWMutex
+
+func registerBodyDecoders(decoders map[string]openapi3filter.BodyDecoder) {
+	if len(decoders) == 0 {
+		return
+	}
+	bodyDecodersMu.Lock()
+	defer bodyDecodersMu.Unlock()
+	for contentType, decoder := range decoders {
+		openapi3filter.RegisterBodyDecoder(contentType, decoder)
+	}
+}
+
+// appendErrors adds the errors to err, collecting all of them in an openapi3.MultiError
+// if there is more than one.
+func appendErrors(err error, errs []error) error {
+	if len(errs) == 0 {
+		return err
+	}
+
+	var all openapi3.MultiError
+	switch e := err.(type) {
+	case nil:
+	case openapi3.MultiError:
+		all = append(all, e...)
+	default:
+		all = append(all, e)
+	}
+	all = append(all, errs...)
+	if len(all) == 1 {
+		return all[0]
+	}
+	return all
+}
@@ -0,0 +1,154 @@
+package validator
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	ut "github.com/go-playground/universal-translator"
+	playground "github.com/go-playground/validator"
+
+	govalidator "request_validator/validator/go_validator"
+	"request_validator/validator/i18n"
+	kinvalidator "request_validator/validator/kin_validator"
+)
+
+// ProblemContentType is the media type of the RFC 7807 problem details documents.
+const ProblemContentType = "application/problem+json"
+
+// Problem is the RFC 7807 problem details document describing a failed request validation.
+type Problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+	// Errors is an extension member listing every invalid field of the request.
+	Errors []Violation `json:"errors,omitempty"`
+}
+
+// NewProblem creates the problem details document of a validation error returned by
+// any of the validator implementations.
+func NewProblem(err error) *Problem {
+	return newProblem(err, nil)
+}
+
+// NewLocalizedProblem creates the problem details document of a validation error with the
+// messages in the language of the translator.
+func NewLocalizedProblem(err error, trans ut.Translator) *Problem {
+	return newProblem(err, trans)
+}
+
+func newProblem(err error, trans ut.Translator) *Problem {
+	status := StatusCode(err)
+	problem := &Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+	}
+
+	validationErr := FromErrorLocalized(err, trans)
+	if validationErr == nil {
+		return problem
+	}
+
+	problem.Errors = validationErr.Violations
+	switch len(problem.Errors) {
+	case 0:
+	case 1:
+		problem.Detail = problem.Errors[0].Message
+	default:
+		problem.Detail = fmt.Sprintf("the request has %d validation errors", len(problem.Errors))
+		if trans != nil {
+			problem.Detail = i18n.Summary(trans, len(problem.Errors))
+		}
+	}
+	return problem
+}
+
+// WriteProblem writes the problem details document of the validation error as the
+// response to the request.
+func WriteProblem(w http.ResponseWriter, r *http.Request, err error) {
+	writeProblem(w, r, NewProblem(err))
+}
+
+// WriteLocalizedProblem writes the problem details document of the validation error as the
+// response to the request, with the messages in the language preferred by its
+// Accept-Language header or, if none is supported, in the fallback one of the translator.
+func WriteLocalizedProblem(w http.ResponseWriter, r *http.Request, err error, t *i18n.Translator) {
+	trans := t.FromRequest(r)
+	w.Header().Set("Content-Language", trans.Locale())
+	writeProblem(w, r, NewLocalizedProblem(err, trans))
+}
+
+func writeProblem(w http.ResponseWriter, r *http.Request, problem *Problem) {
+	if r != nil && r.URL != nil {
+		problem.Instance = r.URL.RequestURI()
+	}
+
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(problem.Status)
+	_ = json.NewEncoder(w).Encode(problem)
+}
+
+// StatusCode returns the http status code that best describes the validation error.
+// Requests that can't be routed get a 404 or a 405, requests without valid credentials
+// a 401, requests lacking the required scopes a 403, requests with an unsupported
+// content type a 415, bodies over the size limit a 413, malformed requests a 400,
+// requests that don't follow the schema a 422 and the errors of the caller, such as an
+// invalid decoding target, a 500.
+func StatusCode(err error) int {
+	switch {
+	case err == nil:
+		return http.StatusOK
+	case isProgrammingError(err):
+		return http.StatusInternalServerError
+	case errors.Is(err, routers.ErrPathNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, routers.ErrMethodNotAllowed):
+		return http.StatusMethodNotAllowed
+	case kinvalidator.IsForbidden(err):
+		return http.StatusForbidden
+	case kinvalidator.IsUnauthenticated(err):
+		return http.StatusUnauthorized
+	case isUnsupportedContentType(err):
+		return http.StatusUnsupportedMediaType
+	case errors.Is(err, govalidator.ErrBodyTooLarge):
+		return http.StatusRequestEntityTooLarge
+	}
+
+	var paramErrs govalidator.ParamErrors
+	if errors.As(err, &paramErrs) {
+		// like the kin-openapi validator, only the params that fail their rules are unprocessable
+		for _, paramErr := range paramErrs {
+			if paramErr.FieldError != nil {
+				return http.StatusUnprocessableEntity
+			}
+		}
+		return http.StatusBadRequest
+	}
+
+	var schemaErr *openapi3.SchemaError
+	var fieldErrs playground.ValidationErrors
+	var mismatchErr *govalidator.TypeMismatchError
+	var unknownErr *govalidator.UnknownFieldError
+	if errors.As(err, &schemaErr) || errors.As(err, &fieldErrs) || errors.As(err, &mismatchErr) || errors.As(err, &unknownErr) {
+		return http.StatusUnprocessableEntity
+	}
+	return http.StatusBadRequest
+}
+
+func isUnsupportedContentType(err error) bool {
+	var requestErr *openapi3filter.RequestError
+	if errors.As(err, &requestErr) && requestErr.Err == nil && strings.HasPrefix(requestErr.Reason, unexpectedContentTypeReason) {
+		return true
+	}
+
+	var parseErr *openapi3filter.ParseError
+	return errors.As(err, &parseErr) && parseErr.Kind == openapi3filter.KindUnsupportedFormat
+}
@@ -0,0 +1,295 @@
+package validator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/stretchr/testify/require"
+
+	govalidator "request_validator/validator/go_validator"
+	"request_validator/validator/i18n"
+	kinvalidator "request_validator/validator/kin_validator"
+)
+
+func TestWriteProblem(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		impl        Implementation
+		method      string
+		url         string
+		contentType string
+		req         string
+		wantStatus  int
+		wantErrors  int
+	}{
+		{
+			name:        "given the kin validator and a request to an unknown server, when we write the problem, a 404 should be returned",
+			impl:        Kin,
+			method:      http.MethodPost,
+			url:         "http://unknown.example.com/v1/users/create",
+			contentType: "application/json",
+			req:         correctRequest,
+			wantStatus:  http.StatusNotFound,
+			wantErrors:  1,
+		},
+		{
+			name:        "given the kin validator and a request with a method that is not defined, when we write the problem, a 405 should be returned",
+			impl:        Kin,
+			method:      http.MethodPut,
+			url:         validURL,
+			contentType: "application/json",
+			req:         correctRequest,
+			wantStatus:  http.StatusMethodNotAllowed,
+			wantErrors:  1,
+		},
+		{
+			name:        "given the kin validator and a request with an unsupported content type, when we write the problem, a 415 should be returned",
+			impl:        Kin,
+			method:      http.MethodPost,
+			url:         validURL,
+			contentType: "text/plain",
+			req:         correctRequest,
+			wantStatus:  http.StatusUnsupportedMediaType,
+			wantErrors:  1,
+		},
+		{
+			name:        "given the kin validator and a request that does not have a required field specified, when we write the problem, a 422 should be returned",
+			impl:        Kin,
+			method:      http.MethodPost,
+			url:         validURL,
+			contentType: "application/json",
+			req:         missingMandatoryFieldRequest,
+			wantStatus:  http.StatusUnprocessableEntity,
+			wantErrors:  1,
+		},
+		{
+			name:        "given the kin validator and a malformed request, when we write the problem, a 400 should be returned",
+			impl:        Kin,
+			method:      http.MethodPost,
+			url:         validURL,
+			contentType: "application/json",
+			req:         `{"id": `,
+			wantStatus:  http.StatusBadRequest,
+			wantErrors:  1,
+		},
+		{
+			name:        "given the go validator and a request that does not have a required field specified, when we write the problem, a 422 should be returned",
+			impl:        Go,
+			method:      http.MethodPost,
+			url:         validURL,
+			contentType: "application/json",
+			req:         missingMandatoryFieldRequest,
+			wantStatus:  http.StatusUnprocessableEntity,
+			wantErrors:  1,
+		},
+		{
+			name:        "given the go validator and a malformed request, when we write the problem, a 400 should be returned",
+			impl:        Go,
+			method:      http.MethodPost,
+			url:         validURL,
+			contentType: "application/json",
+			req:         `{"id": `,
+			wantStatus:  http.StatusBadRequest,
+			wantErrors:  1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			reqValidator := mustCreate(t, tt.impl)
+			httpRequest, err := http.NewRequestWithContext(ctx, tt.method, tt.url, bytes.NewReader([]byte(tt.req)))
+			require.NoError(t, err, "http request creation should not error")
+			httpRequest.Header.Add("Content-Type", tt.contentType)
+			validationErr := reqValidator.ValidateRequest(ctx, httpRequest)
+			require.Error(t, validationErr, "validator should error")
+			recorder := httptest.NewRecorder()
+
+			// act
+			WriteProblem(recorder, httpRequest, validationErr)
+
+			// assert
+			require.Equal(t, tt.wantStatus, recorder.Code)
+			require.Equal(t, ProblemContentType, recorder.Header().Get("Content-Type"))
+
+			var problem Problem
+			require.NoError(t, json.NewDecoder(recorder.Body).Decode(&problem), "problem should be valid json")
+			require.Equal(t, "about:blank", problem.Type)
+			require.Equal(t, http.StatusText(tt.wantStatus), problem.Title)
+			require.Equal(t, tt.wantStatus, problem.Status)
+			require.NotEmpty(t, problem.Detail)
+			require.Len(t, problem.Errors, tt.wantErrors)
+		})
+	}
+}
+
+func TestStatusCodeOfSecurityErrors(t *testing.T) {
+	unauthenticated := &kinvalidator.SecurityError{Scheme: "apiKey", Err: errors.New("missing API key")}
+	forbidden := &kinvalidator.SecurityError{Scheme: "oauth", Forbidden: true, Err: errors.New("missing scope")}
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{
+			name:       "given a request without valid credentials, when we get its status code, a 401 should be returned",
+			err:        &openapi3filter.SecurityRequirementsError{Errors: []error{unauthenticated}},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "given a request that failed a custom authentication function, when we get its status code, a 401 should be returned",
+			err:        &openapi3filter.SecurityRequirementsError{Errors: []error{errors.New("invalid session")}},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "given a request whose credentials lack a required scope, when we get its status code, a 403 should be returned",
+			err:        &openapi3filter.SecurityRequirementsError{Errors: []error{unauthenticated, forbidden}},
+			wantStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// act
+			status := StatusCode(tt.err)
+
+			// assert
+			require.Equal(t, tt.wantStatus, status)
+		})
+	}
+}
+
+func TestStatusCodeOfGoErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{
+			name:       "given a body over the size limit, when we get its status code, a 413 should be returned",
+			err:        fmt.Errorf("%w: the limit is 10 bytes", govalidator.ErrBodyTooLarge),
+			wantStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:       "given a target the body can't be decoded into, when we get its status code, a 500 should be returned",
+			err:        &govalidator.InvalidTargetError{},
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:       "given an empty body, when we get its status code, a 400 should be returned",
+			err:        govalidator.ErrEmptyBody,
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// act
+			status := StatusCode(tt.err)
+
+			// assert
+			require.Equal(t, tt.wantStatus, status)
+		})
+	}
+}
+
+func TestWriteLocalizedProblem(t *testing.T) {
+	ctx := context.Background()
+	translator := i18n.MustNew()
+
+	tests := []struct {
+		name           string
+		impl           Implementation
+		req            string
+		acceptLanguage string
+		wantLanguage   string
+		wantDetail     string
+	}{
+		{
+			name:           "given the kin validator and a Spanish request whose ID is not of a UUID type, when we write the problem, the message should be in Spanish",
+			impl:           Kin,
+			req:            invalidFormatFieldRequest,
+			acceptLanguage: "es-ES,es;q=0.9",
+			wantLanguage:   "es",
+			wantDetail:     "id debe ser un uuid válido",
+		},
+		{
+			name:           "given the go validator and a Spanish request whose ID is not of a UUID type, when we write the problem, the message should be the same as the kin one",
+			impl:           Go,
+			req:            invalidFormatFieldRequest,
+			acceptLanguage: "es",
+			wantLanguage:   "es",
+			wantDetail:     "id debe ser un uuid válido",
+		},
+		{
+			name:           "given the kin validator and a request in an unsupported language, when we write the problem, the message should be in English",
+			impl:           Kin,
+			req:            missingMandatoryFieldRequest,
+			acceptLanguage: "de",
+			wantLanguage:   "en",
+			wantDetail:     "id is required",
+		},
+		{
+			name:         "given the go validator and a request without Accept-Language, when we write the problem, the message should be in English",
+			impl:         Go,
+			req:          missingMandatoryFieldRequest,
+			wantLanguage: "en",
+			wantDetail:   "id is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			reqValidator := mustCreate(t, tt.impl)
+			httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, validURL, bytes.NewReader([]byte(tt.req)))
+			require.NoError(t, err, "http request creation should not error")
+			httpRequest.Header.Add("Content-Type", "application/json")
+			if tt.acceptLanguage != "" {
+				httpRequest.Header.Add("Accept-Language", tt.acceptLanguage)
+			}
+			validationErr := reqValidator.ValidateRequest(ctx, httpRequest)
+			require.Error(t, validationErr, "validator should error")
+			recorder := httptest.NewRecorder()
+
+			// act
+			WriteLocalizedProblem(recorder, httpRequest, validationErr, translator)
+
+			// assert
+			require.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+			require.Equal(t, tt.wantLanguage, recorder.Header().Get("Content-Language"))
+
+			var problem Problem
+			require.NoError(t, json.NewDecoder(recorder.Body).Decode(&problem), "problem should be valid json")
+			require.Equal(t, tt.wantDetail, problem.Detail)
+			require.Len(t, problem.Errors, 1)
+			require.Equal(t, tt.wantDetail, problem.Errors[0].Message)
+		})
+	}
+}
+
+func TestNewProblemOfInvalidTarget(t *testing.T) {
+	// arrange
+	var req struct{}
+	goValidator := govalidator.NewValidator()
+	err := goValidator.ValidateRequest(context.Background(), httptest.NewRequest(http.MethodPost, validURL, nil), req)
+
+	// act
+	problem := NewProblem(err)
+
+	// assert
+	require.Equal(t, http.StatusInternalServerError, problem.Status)
+	require.Empty(t, problem.Errors, "the programming errors should not be reported as violations")
+	require.Empty(t, problem.Detail)
+	require.Empty(t, FromError(err).Violations)
+}
@@ -0,0 +1,217 @@
+// Package shadowvalidator runs two request validators on the same traffic, enforcing
+// the verdict of one of them, and records every request on which they disagree.
+package shadowvalidator
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"request_validator/validator"
+)
+
+const defaultMaxDisagreements = 1000
+
+// Option configures the shadow validator.
+type Option func(*Validator)
+
+// WithMaxDisagreements sets how many disagreements are kept in memory. The disagreements
+// beyond the limit are still counted but not stored.
+func WithMaxDisagreements(max int) Option {
+	return func(v *Validator) {
+		v.maxDisagreements = max
+	}
+}
+
+// WithOnDisagreement sets a function that is called every time the validators disagree.
+func WithOnDisagreement(fn func(d Disagreement)) Option {
+	return func(v *Validator) {
+		v.onDisagreement = fn
+	}
+}
+
+// Request is the snapshot of a request on which the validators disagreed.
+type Request struct {
+	Method string
+	URL    string
+	Header http.Header
+	Body   []byte
+}
+
+// Disagreement is a request that was accepted by one of the validators and rejected by the other.
+type Disagreement struct {
+	Request     Request
+	EnforcedErr error
+	ShadowErr   error
+}
+
+// Results summarizes the verdicts of both validators.
+type Results struct {
+	// Total is the number of validated requests.
+	Total int
+	// Accepted is the number of requests accepted by both validators.
+	Accepted int
+	// Rejected is the number of requests rejected by both validators.
+	Rejected int
+	// EnforcedOnlyRejected is the number of requests rejected only by the enforced validator.
+	EnforcedOnlyRejected int
+	// ShadowOnlyRejected is the number of requests rejected only by the shadow validator.
+	ShadowOnlyRejected int
+	// Disagreements are the recorded requests on which the validators disagreed.
+	Disagreements []Disagreement
+}
+
+// Validator validates every request with both the enforced and the shadow validators and
+// returns the verdict of the enforced one.
+type Validator struct {
+	enforced         validator.RequestValidator
+	shadow           validator.RequestValidator
+	maxDisagreements int
+	onDisagreement   func(d Disagreement)
+
+	mu      sync.Mutex
+	results Results
+}
+
+var _ validator.RequestValidator = (*Validator)(nil)
+
+func NewValidator(enforced, shadow validator.RequestValidator, opts ...Option) *Validator {
+	v := &Validator{
+		enforced:         enforced,
+		shadow:           shadow,
+		maxDisagreements: defaultMaxDisagreements,
+	}
+	for _, opt := range opts {
+		opt(v)
+	}
+	return v
+}
+
+func (v *Validator) ValidateRequest(ctx context.Context, r *http.Request) error {
+
+	// --- (1) ----
+	// Buffer the body so both validators, and the next handlers, can read it.
+	var body []byte
+	if r.Body != nil && r.Body != http.NoBody {
+		var err error
+		body, err = io.ReadAll(r.Body)
+		r.Body.Close()
+		if err != nil {
+			return fmt.Errorf("unable to read request body: %w", err)
+		}
+	}
+
+	// --- (2) ----
+	// Run both validators on their own copy of the request.
+	shadowRq := r.Clone(ctx)
+	shadowRq.Body = io.NopCloser(bytes.NewReader(body))
+	shadowErr := v.shadow.ValidateRequest(ctx, shadowRq)
+
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	enforcedErr := v.enforced.ValidateRequest(ctx, r)
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	// --- (3) ----
+	// Record the verdicts.
+	v.record(r, body, enforcedErr, shadowErr)
+
+	return enforcedErr
+}
+
+func (v *Validator) record(r *http.Request, body []byte, enforcedErr, shadowErr error) {
+	d, disagree := v.count(r, body, enforcedErr, shadowErr)
+
+	// the callback is called without the lock, so it can read the results and a slow one
+	// doesn't hold back the other requests
+	if disagree && v.onDisagreement != nil {
+		v.onDisagreement(d)
+	}
+}
+
+// count records the verdicts of a request and returns the disagreement between them, if any.
+// The returned disagreement is a copy of the recorded one.
+func (v *Validator) count(r *http.Request, body []byte, enforcedErr, shadowErr error) (Disagreement, bool) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.results.Total++
+	switch {
+	case enforcedErr == nil && shadowErr == nil:
+		v.results.Accepted++
+		return Disagreement{}, false
+	case enforcedErr != nil && shadowErr != nil:
+		v.results.Rejected++
+		return Disagreement{}, false
+	case enforcedErr != nil:
+		v.results.EnforcedOnlyRejected++
+	default:
+		v.results.ShadowOnlyRejected++
+	}
+
+	d := Disagreement{
+		Request: Request{
+			Method: r.Method,
+			URL:    r.URL.String(),
+			Header: r.Header.Clone(),
+			Body:   body,
+		},
+		EnforcedErr: enforcedErr,
+		ShadowErr:   shadowErr,
+	}
+	if len(v.results.Disagreements) < v.maxDisagreements {
+		v.results.Disagreements = append(v.results.Disagreements, d)
+	}
+
+	cp := d
+	cp.Request.Header = d.Request.Header.Clone()
+	cp.Request.Body = append([]byte(nil), d.Request.Body...)
+	return cp, true
+}
+
+// Results returns a copy of the results recorded so far.
+func (v *Validator) Results() Results {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	results := v.results
+	results.Disagreements = append([]Disagreement(nil), v.results.Disagreements...)
+	return results
+}
+
+// Reset discards the results recorded so far.
+func (v *Validator) Reset() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.results = Results{}
+}
+
+// Report returns a human readable summary of the results recorded so far.
+func (v *Validator) Report() string {
+	results := v.Results()
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "validated requests: %d\n", results.Total)
+	fmt.Fprintf(&sb, "accepted by both: %d\n", results.Accepted)
+	fmt.Fprintf(&sb, "rejected by both: %d\n", results.Rejected)
+	fmt.Fprintf(&sb, "rejected only by the enforced validator: %d\n", results.EnforcedOnlyRejected)
+	fmt.Fprintf(&sb, "rejected only by the shadow validator: %d\n", results.ShadowOnlyRejected)
+	for i, d := range results.Disagreements {
+		fmt.Fprintf(&sb, "\ndisagreement %d: %s %s\n", i+1, d.Request.Method, d.Request.URL)
+		fmt.Fprintf(&sb, "  body: %s\n", bytes.TrimSpace(d.Request.Body))
+		fmt.Fprintf(&sb, "  enforced: %s\n", verdict(d.EnforcedErr))
+		fmt.Fprintf(&sb, "  shadow: %s\n", verdict(d.ShadowErr))
+	}
+	return sb.String()
+}
+
+func verdict(err error) string {
+	if err == nil {
+		return "accepted"
+	}
+	return "rejected: " + err.Error()
+}
@@ -0,0 +1,149 @@
+package shadowvalidator
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	http_v1 "request_validator/http/v1"
+	http_v2 "request_validator/http/v2"
+	"request_validator/validator"
+)
+
+const correctRequest = `
+{
+	"id": "32d3e8f1-2f81-49c0-acb6-6dccd84f3dab",
+	"firstName": "Jon",
+	"lastName": "Snow"
+}`
+
+const missingMandatoryFieldRequest = `
+{
+	"firstName": "Jon",
+	"lastName": "Snow"
+}
+`
+
+func TestValidator(t *testing.T) {
+	// create the validators
+	ctx := context.Background()
+	swaggerDoc, err := http_v1.GetSwagger()
+	require.NoError(t, err, "swagger recovery should not error")
+	kin, err := validator.New[http_v2.CreateUserReq](ctx, validator.Kin, swaggerDoc)
+	require.NoError(t, err, "kin validator creation should not error")
+	goValidator, err := validator.New[http_v2.CreateUserReq](ctx, validator.Go, nil)
+	require.NoError(t, err, "go validator creation should not error")
+
+	var notified []Disagreement
+	reqValidator := NewValidator(kin, goValidator, WithOnDisagreement(func(d Disagreement) {
+		notified = append(notified, d)
+	}))
+
+	tests := []struct {
+		name    string
+		req     string
+		url     string
+		wantErr bool
+	}{
+		{
+			name: "given a valid request, when we validate it, both validators should accept it",
+			req:  correctRequest,
+			url:  "http://api.example.com/v1/users/create",
+		},
+		{
+			name:    "given a request that does not have a required field specified, when we validate it, both validators should reject it",
+			req:     missingMandatoryFieldRequest,
+			url:     "http://api.example.com/v1/users/create",
+			wantErr: true,
+		},
+		{
+			name:    "given a valid request that does not come from one of the specified url server, when we validate it, only the enforced validator should reject it",
+			req:     correctRequest,
+			url:     "http://unknown.example.com/v1/users/create",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, tt.url, bytes.NewReader([]byte(tt.req)))
+			require.NoError(t, err, "http request creation should not error")
+			httpRequest.Header.Add("Content-Type", "application/json")
+
+			// act
+			err = reqValidator.ValidateRequest(ctx, httpRequest)
+
+			// assert
+			require.Equal(t, tt.wantErr, err != nil)
+			body, err := io.ReadAll(httpRequest.Body)
+			require.NoError(t, err, "body should be readable")
+			require.Equal(t, tt.req, string(body))
+		})
+	}
+
+	results := reqValidator.Results()
+	require.Equal(t, 3, results.Total)
+	require.Equal(t, 1, results.Accepted)
+	require.Equal(t, 1, results.Rejected)
+	require.Equal(t, 1, results.EnforcedOnlyRejected)
+	require.Equal(t, 0, results.ShadowOnlyRejected)
+	require.Len(t, results.Disagreements, 1)
+	require.Equal(t, notified, results.Disagreements)
+
+	d := results.Disagreements[0]
+	require.Equal(t, "http://unknown.example.com/v1/users/create", d.Request.URL)
+	require.Equal(t, correctRequest, string(d.Request.Body))
+	require.Error(t, d.EnforcedErr)
+	require.NoError(t, d.ShadowErr)
+
+	report := reqValidator.Report()
+	require.Contains(t, report, "validated requests: 3")
+	require.Contains(t, report, "rejected only by the enforced validator: 1")
+	require.Contains(t, report, "disagreement 1: POST http://unknown.example.com/v1/users/create")
+
+	reqValidator.Reset()
+	require.Equal(t, 0, reqValidator.Results().Total)
+	require.Empty(t, reqValidator.Results().Disagreements)
+}
+
+func TestValidatorCallbackReadsResults(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	swaggerDoc, err := http_v1.GetSwagger()
+	require.NoError(t, err, "swagger recovery should not error")
+	kin, err := validator.New[http_v2.CreateUserReq](ctx, validator.Kin, swaggerDoc)
+	require.NoError(t, err, "kin validator creation should not error")
+	goValidator, err := validator.New[http_v2.CreateUserReq](ctx, validator.Go, nil)
+	require.NoError(t, err, "go validator creation should not error")
+
+	var reqValidator *Validator
+	var report string
+	reqValidator = NewValidator(kin, goValidator, WithOnDisagreement(func(d Disagreement) {
+		// reading the results from the callback should not deadlock
+		report = reqValidator.Report()
+	}))
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://unknown.example.com/v1/users/create", bytes.NewReader([]byte(correctRequest)))
+	require.NoError(t, err, "http request creation should not error")
+	httpRequest.Header.Add("Content-Type", "application/json")
+
+	// act
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = reqValidator.ValidateRequest(ctx, httpRequest)
+	}()
+
+	// assert
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("the callback deadlocked the validator")
+	}
+	require.Contains(t, report, "rejected only by the enforced validator: 1")
+}
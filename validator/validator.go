@@ -0,0 +1,100 @@
+// Package validator defines the contract shared by every request validator
+// implementation of this project so that services can depend on the
+// interface and pick the implementation through configuration.
+package validator
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/getkin/kin-openapi/openapi3"
+
+	govalidator "request_validator/validator/go_validator"
+	hybridvalidator "request_validator/validator/hybrid_validator"
+	kinvalidator "request_validator/validator/kin_validator"
+)
+
+// Implementation identifies one of the available request validator implementations.
+type Implementation string
+
+const (
+	// Kin validates the request against the OpenAPI document using the kin-openapi library.
+	Kin Implementation = "kin"
+	// Go validates the request body against the generated Go structs using the go-playground library.
+	Go Implementation = "go"
+	// Hybrid validates the server and the params with the kin-openapi library and the
+	// request body with the go-playground library.
+	Hybrid Implementation = "hybrid"
+)
+
+// RequestValidator checks if an incoming http request follows the service's OpenAPI specification.
+type RequestValidator interface {
+	ValidateRequest(ctx context.Context, r *http.Request) error
+}
+
+// RequestValidatorFunc allows the use of ordinary functions as request validators.
+type RequestValidatorFunc func(ctx context.Context, r *http.Request) error
+
+// ValidateRequest calls f(ctx, r).
+func (f RequestValidatorFunc) ValidateRequest(ctx context.Context, r *http.Request) error {
+	return f(ctx, r)
+}
+
+var _ RequestValidator = (*kinvalidator.Validator)(nil)
+
+// FromKinValidator returns the kin-openapi validator as a RequestValidator.
+func FromKinValidator(v *kinvalidator.Validator) RequestValidator {
+	return v
+}
+
+// FromGoValidator returns the go-playground validator as a RequestValidator. Every
+// request body is decoded into a new value of type T before it is validated.
+func FromGoValidator[T any](v govalidator.Validator) RequestValidator {
+	return RequestValidatorFunc(func(ctx context.Context, r *http.Request) error {
+		var req T
+		return v.ValidateRequest(ctx, r, &req)
+	})
+}
+
+// FromHybridValidator returns the hybrid validator as a RequestValidator. Every
+// request body is decoded into a new value of type T before it is validated.
+func FromHybridValidator[T any](v *hybridvalidator.Validator) RequestValidator {
+	return RequestValidatorFunc(func(ctx context.Context, r *http.Request) error {
+		var req T
+		return v.ValidateRequest(ctx, r, &req)
+	})
+}
+
+// New creates the RequestValidator of the given implementation. The kin-openapi
+// implementation validates the requests against doc, the go-playground one decodes
+// the request bodies into values of type T and the hybrid one does both. When doc is
+// given, the go-playground implementation also checks the servers of the document.
+func New[T any](ctx context.Context, impl Implementation, doc *openapi3.T) (RequestValidator, error) {
+	if doc == nil && (impl == Kin || impl == Hybrid) {
+		return nil, fmt.Errorf("the %q validator requires an open api document", impl)
+	}
+
+	switch impl {
+	case Kin:
+		v, err := kinvalidator.NewValidator(ctx, doc)
+		if err != nil {
+			return nil, err
+		}
+		return FromKinValidator(v), nil
+	case Go:
+		var opts []govalidator.Option
+		if doc != nil && len(doc.Servers) > 0 {
+			opts = append(opts, govalidator.WithServers(doc.Servers))
+		}
+		return FromGoValidator[T](govalidator.NewValidator(opts...)), nil
+	case Hybrid:
+		v, err := hybridvalidator.NewValidator(ctx, doc)
+		if err != nil {
+			return nil, err
+		}
+		return FromHybridValidator[T](v), nil
+	default:
+		return nil, fmt.Errorf("unknown validator implementation %q", impl)
+	}
+}
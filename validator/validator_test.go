@@ -0,0 +1,238 @@
+package validator
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	playground "github.com/go-playground/validator"
+	"github.com/stretchr/testify/require"
+
+	http_v1 "request_validator/http/v1"
+	http_v2 "request_validator/http/v2"
+)
+
+const correctRequest = `
+{
+	"id": "32d3e8f1-2f81-49c0-acb6-6dccd84f3dab",
+	"firstName": "Jon",
+	"lastName": "Snow"
+}`
+
+const missingMandatoryFieldRequest = `
+{
+	"firstName": "Jon",
+	"lastName": "Snow"
+}
+`
+
+const invalidFormatFieldRequest = `
+{
+	"id": "sadwefsds",
+	"firstName": "Jon",
+	"lastName": "Snow"
+}`
+
+const validURL = "http://api.example.com/v1/users/create"
+
+func mustCreate(t testing.TB, impl Implementation) RequestValidator {
+	swaggerDoc, err := http_v1.GetSwagger()
+	require.NoError(t, err, "swagger recovery should not error")
+
+	v, err := New[http_v2.CreateUserReq](context.Background(), impl, swaggerDoc)
+	require.NoError(t, err, "validator creation should not error")
+	return v
+}
+
+func TestNew(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := New[http_v2.CreateUserReq](ctx, Implementation("unknown"), nil)
+	require.Error(t, err, "an unknown implementation should error")
+
+	_, err = New[http_v2.CreateUserReq](ctx, Kin, nil)
+	require.Error(t, err, "the kin implementation without a document should error")
+
+	_, err = New[http_v2.CreateUserReq](ctx, Hybrid, nil)
+	require.Error(t, err, "the hybrid implementation without a document should error")
+
+	_, err = New[http_v2.CreateUserReq](ctx, Go, nil)
+	require.NoError(t, err, "the go implementation does not need a document")
+
+	invalidDoc := &openapi3.T{OpenAPI: "3.0.0", Info: &openapi3.Info{Title: "Users"}, Paths: openapi3.NewPaths()}
+	_, err = New[http_v2.CreateUserReq](ctx, Hybrid, invalidDoc)
+	require.Error(t, err, "the hybrid implementation with an invalid document should error")
+}
+
+func TestRequestValidator(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		impl     Implementation
+		req      string
+		url      string
+		wantFunc func(t *testing.T, err error)
+	}{
+		{
+			name: "given the kin validator and a request whose ID is not of a UUID type, when we try to validate it, an error should be returned",
+			impl: Kin,
+			req:  invalidFormatFieldRequest,
+			url:  validURL,
+			wantFunc: func(t *testing.T, err error) {
+				var schemaErr *openapi3.SchemaError
+				require.True(t, errors.As(err, &schemaErr), "error should be of type SchemaError")
+			},
+		},
+		{
+			name: "given the kin validator and a valid request that does not come from one of the specified url server, when we try to validate it, an error should be returned",
+			impl: Kin,
+			req:  correctRequest,
+			url:  "XXXXXXXXXXX",
+			wantFunc: func(t *testing.T, err error) {
+				require.True(t, errors.Is(err, routers.ErrPathNotFound), "error should be of type ErrPathNotFound")
+			},
+		},
+		{
+			name: "given the kin validator and a request that does not have a required field specified, when we try to validate it, an error should be returned",
+			impl: Kin,
+			req:  missingMandatoryFieldRequest,
+			url:  validURL,
+			wantFunc: func(t *testing.T, err error) {
+				var schemaErr *openapi3.SchemaError
+				require.True(t, errors.As(err, &schemaErr), "error should be of type SchemaError")
+			},
+		},
+		{
+			name: "given the kin validator and a malformed request, when we try to validate it, an error should be returned",
+			impl: Kin,
+			req:  `{"id": "32d3e8f1-2f81-49c0-acb6-6dccd84f3dab", "email: "this_is_a_test"}`,
+			url:  validURL,
+			wantFunc: func(t *testing.T, err error) {
+				var parseErr *openapi3filter.ParseError
+				require.True(t, errors.As(err, &parseErr), "error should be of type ParseError")
+			},
+		},
+		{
+			name:     "given the kin validator and a valid request, when we try to validate it, no error should be returned",
+			impl:     Kin,
+			req:      correctRequest,
+			url:      validURL,
+			wantFunc: func(t *testing.T, err error) { require.NoError(t, err, "validator should not error") },
+		},
+		{
+			name: "given the go validator and a valid request that does not come from one of the specified url server, when we try to validate it, an error should be returned",
+			impl: Go,
+			req:  correctRequest,
+			url:  "http://staging.example.com/v1/users/create",
+			wantFunc: func(t *testing.T, err error) {
+				require.True(t, errors.Is(err, routers.ErrPathNotFound), "error should be of type ErrPathNotFound")
+			},
+		},
+		{
+			name: "given the go validator and a request whose ID is not of a UUID type, when we try to validate it, an error should be returned",
+			impl: Go,
+			req:  invalidFormatFieldRequest,
+			url:  validURL,
+			wantFunc: func(t *testing.T, err error) {
+				var validationErrors playground.ValidationErrors
+				require.True(t, errors.As(err, &validationErrors), "error should be of type validator.ValidationErrors")
+			},
+		},
+		{
+			name: "given the go validator and a request that does not have a required field specified, when we try to validate it, an error should be returned",
+			impl: Go,
+			req:  missingMandatoryFieldRequest,
+			url:  validURL,
+			wantFunc: func(t *testing.T, err error) {
+				var validationErrors playground.ValidationErrors
+				require.True(t, errors.As(err, &validationErrors), "error should be of type validator.ValidationErrors")
+			},
+		},
+		{
+			name:     "given the go validator and a valid request, when we try to validate it, no error should be returned",
+			impl:     Go,
+			req:      correctRequest,
+			url:      validURL,
+			wantFunc: func(t *testing.T, err error) { require.NoError(t, err, "validator should not error") },
+		},
+		{
+			name: "given the hybrid validator and a valid request that does not come from one of the specified url server, when we try to validate it, an error should be returned",
+			impl: Hybrid,
+			req:  correctRequest,
+			url:  "XXXXXXXXXXX",
+			wantFunc: func(t *testing.T, err error) {
+				require.True(t, errors.Is(err, routers.ErrPathNotFound), "error should be of type ErrPathNotFound")
+			},
+		},
+		{
+			name: "given the hybrid validator and a request whose ID is not of a UUID type, when we try to validate it, an error should be returned",
+			impl: Hybrid,
+			req:  invalidFormatFieldRequest,
+			url:  validURL,
+			wantFunc: func(t *testing.T, err error) {
+				var validationErrors playground.ValidationErrors
+				require.True(t, errors.As(err, &validationErrors), "error should be of type validator.ValidationErrors")
+			},
+		},
+		{
+			name:     "given the hybrid validator and a valid request, when we try to validate it, no error should be returned",
+			impl:     Hybrid,
+			req:      correctRequest,
+			url:      validURL,
+			wantFunc: func(t *testing.T, err error) { require.NoError(t, err, "validator should not error") },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			var reqValidator RequestValidator = mustCreate(t, tt.impl)
+			httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, tt.url, bytes.NewReader([]byte(tt.req)))
+			require.NoError(t, err, "http request creation should not error")
+			httpRequest.Header.Add("Content-Type", "application/json")
+
+			// act
+			err = reqValidator.ValidateRequest(ctx, httpRequest)
+
+			// assert
+			tt.wantFunc(t, err)
+		})
+	}
+}
+
+func BenchmarkRequestValidator(b *testing.B) {
+	requests := []struct {
+		name string
+		req  string
+	}{
+		{name: "correct request", req: correctRequest},
+		{name: "invalid format request", req: invalidFormatFieldRequest},
+		{name: "missing field request", req: missingMandatoryFieldRequest},
+	}
+
+	for _, impl := range []Implementation{Kin, Go, Hybrid} {
+		for _, rq := range requests {
+			b.Run(fmt.Sprintf("%s validator benchmark with %s", impl, rq.name), func(b *testing.B) {
+				// arrange
+				ctx := context.Background()
+
+				// create the validator
+				reqValidator := mustCreate(b, impl)
+
+				for i := 0; i < b.N; i++ {
+					httpRequest, _ := http.NewRequestWithContext(ctx, http.MethodPost, validURL, bytes.NewReader([]byte(rq.req)))
+					httpRequest.Header.Add("Content-Type", "application/json")
+
+					reqValidator.ValidateRequest(ctx, httpRequest)
+				}
+			})
+		}
+	}
+}